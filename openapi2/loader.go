@@ -0,0 +1,622 @@
+package openapi2
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/go-openapi/jsonpointer"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ReadFromURIFunc defines a function which reads the contents of a resource
+// located at a URI.
+type ReadFromURIFunc func(loader *Loader, url *url.URL) ([]byte, error)
+
+// DefaultReadFromURI reads remote HTTP URIs and local file URIs,
+// with openapi3.DefaultReadFromURI.
+var DefaultReadFromURI ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+	return openapi3.DefaultReadFromURI(nil, location)
+}
+
+// Loader helps deserialize an OpenAPIv2 document.
+//
+// References to other documents are internalized: the schemas, parameters and
+// responses they point to are added to the definitions, parameters and responses
+// of the loaded document, and the references are replaced with references to them.
+// Definitions, parameters and responses which are references to other documents
+// are replaced with what they point to. Schema references are resolved:
+// their Value is set.
+type Loader struct {
+	// IsExternalRefsAllowed enables visiting other files
+	IsExternalRefsAllowed bool
+
+	// ReadFromURIFunc allows overriding the any file/URL reading func
+	ReadFromURIFunc ReadFromURIFunc
+
+	doc      *T
+	location *url.URL
+
+	// documents holds the other documents read, by location
+	documents map[string]interface{}
+	// internalized holds the names given to the targets of references to other documents,
+	// by kind and absolute reference
+	internalized map[string]string
+	// walks holds the deferred resolution of the references within the targets of
+	// the references to other documents that replace definitions, parameters and responses
+	walks []func() error
+
+	resolvingSchemaRef map[*openapi3.SchemaRef]struct{}
+	visitedSchema      map[*openapi3.Schema]struct{}
+}
+
+// NewLoader returns an empty Loader
+func NewLoader() *Loader {
+	return &Loader{}
+}
+
+// LoadFromURI loads a spec from a remote URL
+func (loader *Loader) LoadFromURI(location *url.URL) (*T, error) {
+	data, err := loader.readURL(location)
+	if err != nil {
+		return nil, err
+	}
+	return loader.LoadFromDataWithPath(data, location)
+}
+
+// LoadFromFile loads a spec from a local file path
+func (loader *Loader) LoadFromFile(location string) (*T, error) {
+	return loader.LoadFromURI(&url.URL{Path: filepath.ToSlash(location)})
+}
+
+// LoadFromData loads a spec from a byte array
+func (loader *Loader) LoadFromData(data []byte) (*T, error) {
+	return loader.LoadFromDataWithPath(data, nil)
+}
+
+// LoadFromDataWithPath takes the OpenAPIv2 document data in bytes and a path where the resolver can find referred
+// elements and returns a *T with all resolved data or an error if unable to load data or resolve refs.
+func (loader *Loader) LoadFromDataWithPath(data []byte, location *url.URL) (*T, error) {
+	doc := &T{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, err
+	}
+	if err := loader.ResolveRefsIn(doc, location); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (loader *Loader) readURL(location *url.URL) ([]byte, error) {
+	if f := loader.ReadFromURIFunc; f != nil {
+		return f(loader, location)
+	}
+	return DefaultReadFromURI(loader, location)
+}
+
+// ResolveRefsIn resolves the references of a document, for instance one that was just unmarshaled,
+// whose relative references to other documents are relative to location.
+func (loader *Loader) ResolveRefsIn(doc *T, location *url.URL) error {
+	loader.doc = doc
+	loader.location = location
+	loader.documents = make(map[string]interface{})
+	loader.internalized = make(map[string]string)
+	loader.walks = nil
+	loader.resolvingSchemaRef = make(map[*openapi3.SchemaRef]struct{})
+	loader.visitedSchema = make(map[*openapi3.Schema]struct{})
+	defer func() {
+		loader.doc = nil
+		loader.documents = nil
+	}()
+
+	// Entries which are references to other documents keep their names
+	for _, name := range sortedKeys(doc.Definitions) {
+		if err := loader.replaceDefinition(name); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(doc.Parameters) {
+		if err := loader.replaceParameter(name); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(doc.Responses) {
+		if err := loader.replaceResponse(name); err != nil {
+			return err
+		}
+	}
+	for _, walk := range loader.walks {
+		if err := walk(); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		if err := loader.resolveSchemaRef(doc.Definitions[name], location); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(doc.Parameters) {
+		if err := loader.resolveParameter(doc.Parameters[name], location); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(doc.Responses) {
+		if err := loader.resolveResponse(doc.Responses[name], location); err != nil {
+			return err
+		}
+	}
+	for _, path := range sortedKeys(doc.Paths) {
+		if err := loader.resolvePathItem(doc.Paths[path], location); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// target is what a reference points to.
+type target struct {
+	ref      string
+	location *url.URL
+	fragment string
+}
+
+// key identifies the target of a reference among those of references of a kind.
+func (t *target) key(kind string) string {
+	var location string
+	if t.location != nil {
+		location = t.location.String()
+	}
+	return kind + " " + location + "#" + t.fragment
+}
+
+// name returns the name of the target: the last part of its fragment,
+// or the name of its document trimmed of all extensions.
+func (t *target) name() string {
+	if t.fragment != "" {
+		parts := strings.Split(t.fragment, "/")
+		return jsonpointer.Unescape(parts[len(parts)-1])
+	}
+	name := path.Base(t.location.Path)
+	for ext := path.Ext(name); len(ext) > 0; ext = path.Ext(name) {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// resolveTarget returns what a reference in the document at location points to,
+// and whether it is in the loaded document.
+func (loader *Loader) resolveTarget(ref string, location *url.URL) (*target, bool, error) {
+	parsedURL, err := url.Parse(ref)
+	if err != nil {
+		return nil, false, fmt.Errorf("cannot parse reference: %q: %v", ref, err)
+	}
+	fragment := parsedURL.Fragment
+	if fragment != "" && !strings.HasPrefix(fragment, "/") {
+		return nil, false, fmt.Errorf("expected fragment prefix '#/' in URI %q", ref)
+	}
+	parsedURL.Fragment = ""
+	if parsedURL.String() != "" {
+		location = resolvePath(location, parsedURL)
+	}
+	t := &target{ref: ref, location: location, fragment: fragment}
+	if loader.isRoot(location) {
+		return t, true, nil
+	}
+	if !loader.IsExternalRefsAllowed {
+		return nil, false, fmt.Errorf("encountered disallowed external reference: %q", ref)
+	}
+	return t, false, nil
+}
+
+func (loader *Loader) isRoot(location *url.URL) bool {
+	if location == nil || loader.location == nil {
+		return location == nil && loader.location == nil
+	}
+	return location.String() == loader.location.String()
+}
+
+// load sets value to what the target of a reference to another document points to.
+func (loader *Loader) load(t *target, value interface{}) error {
+	uri := t.location.String()
+	doc, ok := loader.documents[uri]
+	if !ok {
+		data, err := loader.readURL(t.location)
+		if err != nil {
+			return fmt.Errorf("error resolving reference %q: %w", t.ref, err)
+		}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("error resolving reference %q: %w", t.ref, err)
+		}
+		loader.documents[uri] = doc
+	}
+
+	cursor := doc
+	if t.fragment != "" {
+		for _, part := range strings.Split(t.fragment[1:], "/") {
+			part = jsonpointer.Unescape(part)
+			var ok bool
+			switch c := cursor.(type) {
+			case map[string]interface{}:
+				cursor, ok = c[part]
+			case []interface{}:
+				var i int
+				if i, ok = sliceIndex(part, len(c)); ok {
+					cursor = c[i]
+				}
+			}
+			if !ok {
+				return fmt.Errorf("failed to resolve %q in fragment in URI: %q", part, t.ref)
+			}
+		}
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("bad data in %q: %v", t.ref, err)
+	}
+	return nil
+}
+
+func sliceIndex(part string, length int) (int, bool) {
+	i, err := strconv.Atoi(part)
+	return i, err == nil && i >= 0 && i < length
+}
+
+// internalName returns a name for a target that is not among names yet.
+func internalName(t *target, names func(string) bool) string {
+	base := t.name()
+	if base == "" {
+		base = "ref"
+	}
+	name := base
+	for i := 1; names(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+	return name
+}
+
+func (loader *Loader) replaceDefinition(name string) error {
+	schemaRef := loader.doc.Definitions[name]
+	if schemaRef == nil || schemaRef.Ref == "" {
+		return nil
+	}
+	t, local, err := loader.resolveTarget(schemaRef.Ref, loader.location)
+	if err != nil || local {
+		return err
+	}
+	key := t.key("schema")
+	if _, ok := loader.internalized[key]; ok {
+		return nil
+	}
+	schema := &openapi3.Schema{}
+	if err := loader.load(t, schema); err != nil {
+		return err
+	}
+	loader.internalized[key] = name
+	schemaRef.Ref = ""
+	schemaRef.Value = schema
+	loader.walks = append(loader.walks, func() error {
+		return loader.resolveSchema(schema, t.location)
+	})
+	return nil
+}
+
+func (loader *Loader) replaceParameter(name string) error {
+	parameter := loader.doc.Parameters[name]
+	if parameter == nil || parameter.Ref == "" {
+		return nil
+	}
+	t, local, err := loader.resolveTarget(parameter.Ref, loader.location)
+	if err != nil || local {
+		return err
+	}
+	key := t.key("parameter")
+	if _, ok := loader.internalized[key]; ok {
+		return nil
+	}
+	var loaded Parameter
+	if err := loader.load(t, &loaded); err != nil {
+		return err
+	}
+	loader.internalized[key] = name
+	*parameter = loaded
+	loader.walks = append(loader.walks, func() error {
+		return loader.resolveParameter(parameter, t.location)
+	})
+	return nil
+}
+
+func (loader *Loader) replaceResponse(name string) error {
+	response := loader.doc.Responses[name]
+	if response == nil || response.Ref == "" {
+		return nil
+	}
+	t, local, err := loader.resolveTarget(response.Ref, loader.location)
+	if err != nil || local {
+		return err
+	}
+	key := t.key("response")
+	if _, ok := loader.internalized[key]; ok {
+		return nil
+	}
+	var loaded Response
+	if err := loader.load(t, &loaded); err != nil {
+		return err
+	}
+	loader.internalized[key] = name
+	*response = loaded
+	loader.walks = append(loader.walks, func() error {
+		return loader.resolveResponse(response, t.location)
+	})
+	return nil
+}
+
+func (loader *Loader) resolveSchemaRef(schemaRef *openapi3.SchemaRef, location *url.URL) error {
+	if schemaRef == nil {
+		return nil
+	}
+	if schemaRef.Ref == "" {
+		return loader.resolveSchema(schemaRef.Value, location)
+	}
+
+	t, local, err := loader.resolveTarget(schemaRef.Ref, location)
+	if err != nil {
+		return err
+	}
+	doc := loader.doc
+	if local {
+		name, err := localRef("#"+t.fragment, "definitions")
+		if err != nil {
+			return fmt.Errorf("found unresolved ref: %q", schemaRef.Ref)
+		}
+		definition := doc.Definitions[name]
+		if definition == nil {
+			return fmt.Errorf("found unresolved ref: %q", schemaRef.Ref)
+		}
+		if definition.Ref != "" && definition.Value == nil {
+			if _, ok := loader.resolvingSchemaRef[definition]; ok {
+				return fmt.Errorf("found circular ref: %q", schemaRef.Ref)
+			}
+			loader.resolvingSchemaRef[definition] = struct{}{}
+			if err := loader.resolveSchemaRef(definition, loader.location); err != nil {
+				return err
+			}
+		}
+		schemaRef.Ref = "#" + t.fragment
+		schemaRef.Value = definition.Value
+		return nil
+	}
+
+	key := t.key("schema")
+	if name, ok := loader.internalized[key]; ok {
+		schemaRef.Ref = "#/definitions/" + jsonpointer.Escape(name)
+		schemaRef.Value = doc.Definitions[name].Value
+		return nil
+	}
+	schema := &openapi3.Schema{}
+	if err := loader.load(t, schema); err != nil {
+		return err
+	}
+	if doc.Definitions == nil {
+		doc.Definitions = make(map[string]*openapi3.SchemaRef)
+	}
+	name := internalName(t, func(name string) bool {
+		_, ok := doc.Definitions[name]
+		return ok
+	})
+	doc.Definitions[name] = &openapi3.SchemaRef{Value: schema}
+	loader.internalized[key] = name
+	schemaRef.Ref = "#/definitions/" + jsonpointer.Escape(name)
+	schemaRef.Value = schema
+	return loader.resolveSchema(schema, t.location)
+}
+
+func (loader *Loader) resolveSchema(schema *openapi3.Schema, location *url.URL) error {
+	if schema == nil {
+		return nil
+	}
+	if _, ok := loader.visitedSchema[schema]; ok {
+		return nil
+	}
+	loader.visitedSchema[schema] = struct{}{}
+
+	if err := loader.resolveSchemaRef(schema.Items, location); err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if err := loader.resolveSchemaRef(schema.Properties[name], location); err != nil {
+			return err
+		}
+	}
+	if err := loader.resolveSchemaRef(schema.AdditionalProperties, location); err != nil {
+		return err
+	}
+	if err := loader.resolveSchemaRef(schema.Not, location); err != nil {
+		return err
+	}
+	for _, schemaRefs := range []openapi3.SchemaRefs{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for _, schemaRef := range schemaRefs {
+			if err := loader.resolveSchemaRef(schemaRef, location); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (loader *Loader) resolveParameter(parameter *Parameter, location *url.URL) error {
+	if parameter == nil {
+		return nil
+	}
+	if parameter.Ref == "" {
+		if err := loader.resolveSchemaRef(parameter.Schema, location); err != nil {
+			return err
+		}
+		return loader.resolveSchemaRef(parameter.Items, location)
+	}
+
+	t, local, err := loader.resolveTarget(parameter.Ref, location)
+	if err != nil {
+		return err
+	}
+	doc := loader.doc
+	if local {
+		name, err := localRef("#"+t.fragment, "parameters")
+		if err != nil || doc.Parameters[name] == nil {
+			return fmt.Errorf("found unresolved ref: %q", parameter.Ref)
+		}
+		parameter.Ref = "#" + t.fragment
+		return nil
+	}
+
+	key := t.key("parameter")
+	name, ok := loader.internalized[key]
+	if !ok {
+		loaded := &Parameter{}
+		if err := loader.load(t, loaded); err != nil {
+			return err
+		}
+		if doc.Parameters == nil {
+			doc.Parameters = make(map[string]*Parameter)
+		}
+		name = internalName(t, func(name string) bool {
+			_, ok := doc.Parameters[name]
+			return ok
+		})
+		doc.Parameters[name] = loaded
+		loader.internalized[key] = name
+		if err := loader.resolveParameter(loaded, t.location); err != nil {
+			return err
+		}
+	}
+	*parameter = Parameter{Ref: "#/parameters/" + jsonpointer.Escape(name)}
+	return nil
+}
+
+func (loader *Loader) resolveResponse(response *Response, location *url.URL) error {
+	if response == nil {
+		return nil
+	}
+	if response.Ref == "" {
+		if err := loader.resolveSchemaRef(response.Schema, location); err != nil {
+			return err
+		}
+		for _, name := range sortedKeys(response.Headers) {
+			if header := response.Headers[name]; header != nil {
+				if err := loader.resolveSchemaRef(header.Items, location); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	t, local, err := loader.resolveTarget(response.Ref, location)
+	if err != nil {
+		return err
+	}
+	doc := loader.doc
+	if local {
+		name, err := localRef("#"+t.fragment, "responses")
+		if err != nil || doc.Responses[name] == nil {
+			return fmt.Errorf("found unresolved ref: %q", response.Ref)
+		}
+		response.Ref = "#" + t.fragment
+		return nil
+	}
+
+	key := t.key("response")
+	name, ok := loader.internalized[key]
+	if !ok {
+		loaded := &Response{}
+		if err := loader.load(t, loaded); err != nil {
+			return err
+		}
+		if doc.Responses == nil {
+			doc.Responses = make(map[string]*Response)
+		}
+		name = internalName(t, func(name string) bool {
+			_, ok := doc.Responses[name]
+			return ok
+		})
+		doc.Responses[name] = loaded
+		loader.internalized[key] = name
+		if err := loader.resolveResponse(loaded, t.location); err != nil {
+			return err
+		}
+	}
+	*response = Response{Ref: "#/responses/" + jsonpointer.Escape(name)}
+	return nil
+}
+
+func (loader *Loader) resolvePathItem(pathItem *PathItem, location *url.URL) error {
+	if pathItem == nil {
+		return nil
+	}
+	// The path item may itself be a reference, relative to its document
+	visited := make(map[string]struct{})
+	for pathItem.Ref != "" {
+		ref := pathItem.Ref
+		t, local, err := loader.resolveTarget(ref, location)
+		if err != nil {
+			return err
+		}
+		if local {
+			return fmt.Errorf("found unresolved ref: %q", ref)
+		}
+		key := t.location.String() + "#" + t.fragment
+		if _, ok := visited[key]; ok {
+			return fmt.Errorf("found cyclic path item ref: %q", ref)
+		}
+		visited[key] = struct{}{}
+		var loaded PathItem
+		if err := loader.load(t, &loaded); err != nil {
+			return err
+		}
+		*pathItem = loaded
+		location = t.location
+	}
+
+	for _, parameter := range pathItem.Parameters {
+		if err := loader.resolveParameter(parameter, location); err != nil {
+			return err
+		}
+	}
+	operations := pathItem.Operations()
+	for _, method := range sortedKeys(operations) {
+		operation := operations[method]
+		for _, parameter := range operation.Parameters {
+			if err := loader.resolveParameter(parameter, location); err != nil {
+				return err
+			}
+		}
+		for _, code := range sortedKeys(operation.Responses) {
+			if err := loader.resolveResponse(operation.Responses[code], location); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolvePath returns the location of a reference relative to the document at base.
+func resolvePath(base *url.URL, ref *url.URL) *url.URL {
+	if base == nil || ref.Scheme != "" || ref.Host != "" || strings.HasPrefix(ref.Path, "/") {
+		return ref
+	}
+	resolved := *base
+	resolved.Path = path.Join(path.Dir(base.Path), ref.Path)
+	resolved.RawPath = ""
+	resolved.RawQuery = ref.RawQuery
+	return &resolved
+}
@@ -0,0 +1,153 @@
+package openapi2_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi2"
+)
+
+func TestLoaderExternalRefs(t *testing.T) {
+	loader := openapi2.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc, err := loader.LoadFromFile("testdata/refs/swagger.yaml")
+	require.NoError(t, err)
+
+	err = doc.Validate(context.Background())
+	require.NoError(t, err)
+
+	user := doc.Definitions["User"].Value
+	require.NotNil(t, user)
+	require.Same(t, user, doc.Paths["/users/{id}"].Get.Responses["200"].Schema.Value)
+	require.Same(t, user, user.Properties["friends"].Value.Items.Value)
+	require.Same(t, doc.Definitions["Address"].Value, user.Properties["address"].Value)
+	require.Same(t, doc.Definitions["Pet"].Value, doc.Paths["/pets"].Get.Responses["200"].Schema.Value.Items.Value)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "swagger": "2.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "paths": {
+    "/pets": {
+      "get": {
+        "responses": {
+          "200": {"description": "OK", "schema": {"type": "array", "items": {"$ref": "#/definitions/Pet"}}}
+        }
+      }
+    },
+    "/users/{id}": {
+      "get": {
+        "parameters": [{"$ref": "#/parameters/UserID"}],
+        "responses": {
+          "200": {"description": "OK", "schema": {"$ref": "#/definitions/User"}},
+          "default": {"$ref": "#/responses/Error"}
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Address": {"type": "object", "properties": {"city": {"type": "string"}}},
+    "Error": {"type": "object", "properties": {"message": {"type": "string"}}},
+    "Pet": {"type": "object", "properties": {"owner": {"$ref": "#/definitions/User"}}},
+    "User": {
+      "type": "object",
+      "properties": {
+        "address": {"$ref": "#/definitions/Address"},
+        "friends": {"type": "array", "items": {"$ref": "#/definitions/User"}}
+      }
+    }
+  },
+  "parameters": {
+    "UserID": {"in": "path", "name": "id", "required": true, "type": "string"}
+  },
+  "responses": {
+    "Error": {"description": "Error", "schema": {"$ref": "#/definitions/Error"}}
+  }
+}`, string(data))
+}
+
+func TestLoaderDisallowsExternalRefs(t *testing.T) {
+	_, err := openapi2.NewLoader().LoadFromFile("testdata/refs/swagger.yaml")
+	require.EqualError(t, err, `encountered disallowed external reference: "definitions/error.yaml"`)
+}
+
+func TestLoaderReadFromURIFunc(t *testing.T) {
+	files := map[string]string{
+		"specs/swagger.yaml": `
+swagger: "2.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK
+          schema: {$ref: "schemas.yaml#/Pet"}
+definitions:
+  Pet: {type: string}
+`,
+		"specs/schemas.yaml": `
+Pet:
+  type: object
+  properties:
+    name: {type: string}
+`,
+	}
+	loader := openapi2.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi2.Loader, location *url.URL) ([]byte, error) {
+		return []byte(files[location.Path]), nil
+	}
+	doc, err := loader.LoadFromURI(&url.URL{Path: "specs/swagger.yaml"})
+	require.NoError(t, err)
+
+	// The name of the local definition is kept
+	schema := doc.Paths["/pets"].Get.Responses["200"].Schema
+	require.Equal(t, "#/definitions/Pet1", schema.Ref)
+	require.Equal(t, "object", schema.Value.Type)
+	require.Equal(t, "string", doc.Definitions["Pet"].Value.Type)
+}
+
+func TestLoaderCyclicPathItemRef(t *testing.T) {
+	for paths, expected := range map[string]string{
+		`pets: {$ref: "#/pets"}`:             `found cyclic path item ref: "#/pets"`,
+		`pets: {$ref: "more.yaml#/pets"}`:    `found cyclic path item ref: "paths.yaml#/pets"`,
+		`pets: {$ref: "swagger.yaml#/pets"}`: `found unresolved ref: "swagger.yaml#/pets"`,
+	} {
+		files := map[string]string{
+			"specs/swagger.yaml": `
+swagger: "2.0"
+info: {title: Pets, version: 1.0.0}
+paths:
+  /pets: {$ref: "paths.yaml#/pets"}
+`,
+			"specs/paths.yaml": paths,
+			"specs/more.yaml":  `pets: {$ref: "paths.yaml#/pets"}`,
+		}
+		loader := openapi2.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		loader.ReadFromURIFunc = func(loader *openapi2.Loader, location *url.URL) ([]byte, error) {
+			return []byte(files[location.Path]), nil
+		}
+		_, err := loader.LoadFromURI(&url.URL{Path: "specs/swagger.yaml"})
+		require.EqualError(t, err, expected)
+	}
+}
+
+func TestLoaderUnresolvedRef(t *testing.T) {
+	_, err := openapi2.NewLoader().LoadFromData([]byte(`
+swagger: "2.0"
+info: {title: Pets, version: 1.0.0}
+paths: {}
+definitions:
+  Pet:
+    properties:
+      owner: {$ref: "#/definitions/User"}
+`))
+	require.EqualError(t, err, `found unresolved ref: "#/definitions/User"`)
+}
@@ -1,9 +1,14 @@
 package openapi2
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -39,6 +44,414 @@ func (doc *T) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, doc)
 }
 
+// Validate returns an error if T does not comply with the Swagger 2.0 spec.
+// References to parameters, responses, definitions and security definitions
+// must be local to the document and point to an existing entry.
+func (doc *T) Validate(ctx context.Context) error {
+	if doc.Swagger != "2.0" {
+		return fmt.Errorf("value of swagger must be %q, got %q", "2.0", doc.Swagger)
+	}
+
+	// NOTE: only mention info/paths/definitions/... key in this func's errors.
+
+	if err := doc.Info.Validate(ctx); err != nil {
+		return fmt.Errorf("invalid info: %w", err)
+	}
+
+	if strings.Contains(doc.Host, "://") || strings.Contains(doc.Host, "/") {
+		return fmt.Errorf("invalid host: %q must not include a scheme or a path", doc.Host)
+	}
+	if basePath := doc.BasePath; basePath != "" && basePath[0] != '/' {
+		return fmt.Errorf("invalid basePath: %q does not start with a forward slash (/)", basePath)
+	}
+	if err := validateSchemes(doc.Schemes); err != nil {
+		return fmt.Errorf("invalid schemes: %w", err)
+	}
+
+	for _, name := range sortedKeys(doc.Parameters) {
+		if err := doc.validateParameter(ctx, doc.Parameters[name]); err != nil {
+			return fmt.Errorf("invalid parameters: parameter %q: %w", name, err)
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Responses) {
+		if err := doc.validateResponse(ctx, doc.Responses[name]); err != nil {
+			return fmt.Errorf("invalid responses: response %q: %w", name, err)
+		}
+	}
+
+	for _, name := range sortedKeys(doc.Definitions) {
+		if err := doc.validateSchemaRef(doc.Definitions[name]); err != nil {
+			return fmt.Errorf("invalid definitions: definition %q: %w", name, err)
+		}
+	}
+
+	for _, name := range sortedKeys(doc.SecurityDefinitions) {
+		securityScheme := doc.SecurityDefinitions[name]
+		if securityScheme == nil {
+			return fmt.Errorf("invalid securityDefinitions: security scheme %q: value must be an object", name)
+		}
+		if err := securityScheme.Validate(ctx); err != nil {
+			return fmt.Errorf("invalid securityDefinitions: security scheme %q: %w", name, err)
+		}
+	}
+
+	if err := doc.validateSecurity(doc.Security); err != nil {
+		return fmt.Errorf("invalid security: %w", err)
+	}
+
+	if doc.Paths == nil {
+		return errors.New("invalid paths: must be an object")
+	}
+	operationIDs := make(map[string]string)
+	for _, path := range sortedKeys(doc.Paths) {
+		pathItem := doc.Paths[path]
+		if path == "" || path[0] != '/' {
+			return fmt.Errorf("invalid paths: path %q does not start with a forward slash (/)", path)
+		}
+		if pathItem == nil {
+			continue
+		}
+		if err := doc.validatePathItem(ctx, path, pathItem); err != nil {
+			return fmt.Errorf("invalid paths: path %q: %w", path, err)
+		}
+		for _, method := range sortedKeys(pathItem.Operations()) {
+			operationID := pathItem.GetOperation(method).OperationID
+			if operationID == "" {
+				continue
+			}
+			operation := method + " " + path
+			if other, ok := operationIDs[operationID]; ok {
+				return fmt.Errorf("invalid paths: operations %s and %s have the same operationId %q", other, operation, operationID)
+			}
+			operationIDs[operationID] = operation
+		}
+	}
+
+	if err := doc.Tags.Validate(ctx); err != nil {
+		return fmt.Errorf("invalid tags: %w", err)
+	}
+
+	if v := doc.ExternalDocs; v != nil {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("invalid external docs: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func validateSchemes(schemes []string) error {
+	for _, scheme := range schemes {
+		switch scheme {
+		case "http", "https", "ws", "wss":
+		default:
+			return fmt.Errorf("unsupported scheme %q", scheme)
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of a map with string keys, sorted.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+	keys := make([]string, 0, v.Len())
+	for _, key := range v.MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// localRef returns the name of the entry of a section of the document
+// a reference points to, such as "Pet" for "#/definitions/Pet".
+func localRef(ref, section string) (string, error) {
+	prefix := "#/" + section + "/"
+	if !strings.HasPrefix(ref, prefix) || len(ref) == len(prefix) {
+		return "", fmt.Errorf("reference %q does not point to %s", ref, prefix)
+	}
+	name := ref[len(prefix):]
+	return strings.Replace(strings.Replace(name, "~1", "/", -1), "~0", "~", -1), nil
+}
+
+// resolveParameter returns the parameter a parameter refers to, or the parameter itself.
+func (doc *T) resolveParameter(parameter *Parameter) (*Parameter, error) {
+	if parameter.Ref == "" {
+		return parameter, nil
+	}
+	name, err := localRef(parameter.Ref, "parameters")
+	if err != nil {
+		return nil, err
+	}
+	resolved := doc.Parameters[name]
+	if resolved == nil {
+		return nil, fmt.Errorf("parameter %q referenced by %q is not defined", name, parameter.Ref)
+	}
+	return resolved, nil
+}
+
+func (doc *T) validateParameter(ctx context.Context, parameter *Parameter) error {
+	if parameter == nil {
+		return errors.New("value must be an object")
+	}
+	if parameter.Ref != "" {
+		_, err := doc.resolveParameter(parameter)
+		return err
+	}
+	if err := parameter.Validate(ctx); err != nil {
+		return err
+	}
+	if err := doc.validateSchemaRef(parameter.Schema); err != nil {
+		return fmt.Errorf("parameter %q schema is invalid: %w", parameter.Name, err)
+	}
+	if err := doc.validateSchemaRef(parameter.Items); err != nil {
+		return fmt.Errorf("parameter %q items are invalid: %w", parameter.Name, err)
+	}
+	return nil
+}
+
+func (doc *T) validateResponse(ctx context.Context, response *Response) error {
+	if response == nil {
+		return errors.New("value must be an object")
+	}
+	if ref := response.Ref; ref != "" {
+		name, err := localRef(ref, "responses")
+		if err != nil {
+			return err
+		}
+		if doc.Responses[name] == nil {
+			return fmt.Errorf("response %q referenced by %q is not defined", name, ref)
+		}
+		return nil
+	}
+	if err := response.Validate(ctx); err != nil {
+		return err
+	}
+	if err := doc.validateSchemaRef(response.Schema); err != nil {
+		return fmt.Errorf("response schema is invalid: %w", err)
+	}
+	return nil
+}
+
+// validateSchemaRef checks the references to definitions within a schema.
+func (doc *T) validateSchemaRef(schemaRef *openapi3.SchemaRef) error {
+	return doc.validateSchemaRefIn(schemaRef, make(map[*openapi3.Schema]struct{}))
+}
+
+func (doc *T) validateSchemaRefIn(schemaRef *openapi3.SchemaRef, visited map[*openapi3.Schema]struct{}) error {
+	if schemaRef == nil {
+		return nil
+	}
+	if ref := schemaRef.Ref; ref != "" {
+		if ref[0] != '#' {
+			// A reference to another document
+			return nil
+		}
+		name, err := localRef(ref, "definitions")
+		if err != nil {
+			return err
+		}
+		if doc.Definitions[name] == nil {
+			return fmt.Errorf("definition %q referenced by %q is not defined", name, ref)
+		}
+		return nil
+	}
+	schema := schemaRef.Value
+	if schema == nil {
+		return nil
+	}
+	if _, ok := visited[schema]; ok {
+		return nil
+	}
+	visited[schema] = struct{}{}
+
+	refs := []*openapi3.SchemaRef{schema.Items, schema.AdditionalProperties, schema.Not}
+	refs = append(refs, schema.AllOf...)
+	refs = append(refs, schema.AnyOf...)
+	refs = append(refs, schema.OneOf...)
+	for _, ref := range refs {
+		if err := doc.validateSchemaRefIn(ref, visited); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(schema.Properties) {
+		if err := doc.validateSchemaRefIn(schema.Properties[name], visited); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// validateSecurity checks security requirements refer to security definitions and their scopes.
+func (doc *T) validateSecurity(security SecurityRequirements) error {
+	for _, requirement := range security {
+		for _, name := range sortedKeys(requirement) {
+			securityScheme := doc.SecurityDefinitions[name]
+			if securityScheme == nil {
+				return fmt.Errorf("security definition %q is not defined", name)
+			}
+			scopes := requirement[name]
+			if securityScheme.Type != "oauth2" {
+				if len(scopes) != 0 {
+					return fmt.Errorf("security definition %q of type %q must not list scopes", name, securityScheme.Type)
+				}
+				continue
+			}
+			for _, scope := range scopes {
+				if _, ok := securityScheme.Scopes[scope]; !ok {
+					return fmt.Errorf("scope %q is not defined by security definition %q", scope, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (doc *T) validatePathItem(ctx context.Context, path string, pathItem *PathItem) error {
+	if pathItem.Ref != "" {
+		// Path items are only referenced in other documents
+		return nil
+	}
+	parameters, err := doc.resolveParameters(ctx, pathItem.Parameters)
+	if err != nil {
+		return err
+	}
+
+	for _, method := range sortedKeys(pathItem.Operations()) {
+		operation := pathItem.GetOperation(method)
+		if err := doc.validateOperation(ctx, path, parameters, operation); err != nil {
+			return fmt.Errorf("operation %s: %w", method, err)
+		}
+	}
+	return nil
+}
+
+// resolveParameters validates parameters and resolves their references.
+func (doc *T) resolveParameters(ctx context.Context, parameters Parameters) (Parameters, error) {
+	resolved := make(Parameters, 0, len(parameters))
+	seen := make(map[[2]string]struct{}, len(parameters))
+	for i, parameter := range parameters {
+		if err := doc.validateParameter(ctx, parameter); err != nil {
+			return nil, fmt.Errorf("parameter #%d: %w", i, err)
+		}
+		parameter, _ = doc.resolveParameter(parameter)
+		key := [2]string{parameter.Name, parameter.In}
+		if _, ok := seen[key]; ok {
+			return nil, fmt.Errorf("%s parameter %q is defined more than once", parameter.In, parameter.Name)
+		}
+		seen[key] = struct{}{}
+		resolved = append(resolved, parameter)
+	}
+	return resolved, nil
+}
+
+func (doc *T) validateOperation(ctx context.Context, path string, pathParameters Parameters, operation *Operation) error {
+	if err := operation.Validate(ctx); err != nil {
+		return err
+	}
+
+	operationParameters, err := doc.resolveParameters(ctx, operation.Parameters)
+	if err != nil {
+		return err
+	}
+	// Operation parameters override the path item parameters with the same name and location
+	parameters := append(Parameters{}, operationParameters...)
+	for _, parameter := range pathParameters {
+		overridden := false
+		for _, p := range operationParameters {
+			if p.Name == parameter.Name && p.In == parameter.In {
+				overridden = true
+				break
+			}
+		}
+		if !overridden {
+			parameters = append(parameters, parameter)
+		}
+	}
+
+	consumes := operation.Consumes
+	if consumes == nil {
+		consumes = doc.Consumes
+	}
+	var body, form *Parameter
+	pathParameterNames := make(map[string]struct{})
+	for _, parameter := range parameters {
+		switch parameter.In {
+		case "body":
+			if body != nil {
+				return fmt.Errorf("body parameters %q and %q are defined, but an operation can have only one", body.Name, parameter.Name)
+			}
+			body = parameter
+		case "formData":
+			form = parameter
+			if parameter.Type == "file" && !containsMediaType(consumes, "multipart/form-data", "application/x-www-form-urlencoded") {
+				return fmt.Errorf("file parameter %q requires the operation to consume multipart/form-data or application/x-www-form-urlencoded", parameter.Name)
+			}
+		case "path":
+			pathParameterNames[parameter.Name] = struct{}{}
+		}
+	}
+	if body != nil && form != nil {
+		return fmt.Errorf("body parameter %q and formData parameter %q are defined, but an operation cannot have both", body.Name, form.Name)
+	}
+
+	templateNames := pathTemplateNames(path)
+	for _, name := range templateNames {
+		if _, ok := pathParameterNames[name]; !ok {
+			return fmt.Errorf("path parameter %q is not defined", name)
+		}
+		delete(pathParameterNames, name)
+	}
+	if names := sortedKeys(pathParameterNames); len(names) != 0 {
+		return fmt.Errorf("path parameter %q does not appear in the path", names[0])
+	}
+
+	for _, code := range sortedKeys(operation.Responses) {
+		if err := doc.validateResponse(ctx, operation.Responses[code]); err != nil {
+			return fmt.Errorf("response %q: %w", code, err)
+		}
+	}
+
+	if security := operation.Security; security != nil {
+		if err := doc.validateSecurity(*security); err != nil {
+			return fmt.Errorf("invalid security: %w", err)
+		}
+	}
+	return nil
+}
+
+func containsMediaType(mediaTypes []string, expected ...string) bool {
+	for _, mediaType := range mediaTypes {
+		if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+			mediaType = mediaType[:i]
+		}
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		for _, e := range expected {
+			if mediaType == e {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pathTemplateNames returns the names of the parameters of a templated path, such as "id" in "/pets/{id}".
+func pathTemplateNames(path string) []string {
+	var names []string
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			return names
+		}
+		end := strings.IndexByte(path[start:], '}')
+		if end < 0 {
+			return names
+		}
+		names = append(names, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
+
 func (doc *T) AddOperation(path string, method string, operation *Operation) {
 	paths := doc.Paths
 	if paths == nil {
@@ -169,6 +582,31 @@ func (operation *Operation) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, operation)
 }
 
+// Validate returns an error if Operation does not comply with the Swagger 2.0 spec.
+// Its parameters and responses are validated by T.Validate, which resolves their references.
+func (operation *Operation) Validate(ctx context.Context) error {
+	if len(operation.Responses) == 0 {
+		return errors.New("value of responses must be a non-empty object")
+	}
+	for code := range operation.Responses {
+		if code == "default" || strings.HasPrefix(code, "x-") {
+			continue
+		}
+		if status, err := strconv.Atoi(code); err != nil || status < 100 || status > 599 {
+			return fmt.Errorf("response code %q must be a status code or default", code)
+		}
+	}
+	if err := validateSchemes(operation.Schemes); err != nil {
+		return err
+	}
+	if v := operation.ExternalDocs; v != nil {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("invalid external docs: %w", err)
+		}
+	}
+	return nil
+}
+
 type Parameters []*Parameter
 
 var _ sort.Interface = Parameters{}
@@ -223,6 +661,82 @@ func (parameter *Parameter) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, parameter)
 }
 
+// Validate returns an error if Parameter does not comply with the Swagger 2.0 spec.
+// The references of parameters are validated by T.Validate.
+func (parameter *Parameter) Validate(ctx context.Context) error {
+	if parameter.Ref != "" {
+		return nil
+	}
+	if parameter.Name == "" {
+		return errors.New("parameter name can't be blank")
+	}
+	switch parameter.In {
+	case "query", "header", "path", "formData":
+	case "body":
+		if parameter.Schema == nil {
+			return fmt.Errorf("body parameter %q must have a schema", parameter.Name)
+		}
+		if parameter.Type != "" {
+			return fmt.Errorf("body parameter %q must not have a type, its schema has", parameter.Name)
+		}
+		return nil
+	default:
+		return fmt.Errorf("parameter can't have 'in' value %q", parameter.In)
+	}
+
+	if parameter.In == "path" && !parameter.Required {
+		return fmt.Errorf("path parameter %q must be required", parameter.Name)
+	}
+	if parameter.Schema != nil {
+		return fmt.Errorf("%s parameter %q must not have a schema, only body parameters have one", parameter.In, parameter.Name)
+	}
+	if err := validateSimpleType(parameter.Type, parameter.Items, parameter.In == "formData"); err != nil {
+		return fmt.Errorf("%s parameter %q: %w", parameter.In, parameter.Name, err)
+	}
+	if err := validateCollectionFormat(parameter.CollectionFormat, parameter.Type, parameter.In == "query" || parameter.In == "formData"); err != nil {
+		return fmt.Errorf("%s parameter %q: %w", parameter.In, parameter.Name, err)
+	}
+	return nil
+}
+
+// validateSimpleType checks the type of a non-body parameter or of a header.
+func validateSimpleType(typ string, items *openapi3.SchemaRef, allowFile bool) error {
+	switch typ {
+	case "string", "number", "integer", "boolean":
+	case "array":
+		if items == nil {
+			return errors.New("items must be defined for type array")
+		}
+	case "file":
+		if !allowFile {
+			return errors.New("type file is only allowed for formData parameters")
+		}
+	case "":
+		return errors.New("type must be defined")
+	default:
+		return fmt.Errorf("unsupported type %q", typ)
+	}
+	return nil
+}
+
+func validateCollectionFormat(collectionFormat, typ string, allowMulti bool) error {
+	switch collectionFormat {
+	case "":
+		return nil
+	case "csv", "ssv", "tsv", "pipes":
+	case "multi":
+		if !allowMulti {
+			return errors.New("collectionFormat multi is only allowed for query and formData parameters")
+		}
+	default:
+		return fmt.Errorf("unsupported collectionFormat %q", collectionFormat)
+	}
+	if typ != "array" {
+		return fmt.Errorf("collectionFormat %q requires type array", collectionFormat)
+	}
+	return nil
+}
+
 type Response struct {
 	openapi3.ExtensionProps
 	Ref         string                 `json:"$ref,omitempty" yaml:"$ref,omitempty"`
@@ -242,6 +756,27 @@ func (response *Response) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, response)
 }
 
+// Validate returns an error if Response does not comply with the Swagger 2.0 spec.
+// The references of responses are validated by T.Validate.
+func (response *Response) Validate(ctx context.Context) error {
+	if response.Ref != "" {
+		return nil
+	}
+	if response.Description == "" {
+		return errors.New("value of description must be a non-empty string")
+	}
+	for _, name := range sortedKeys(response.Headers) {
+		header := response.Headers[name]
+		if header == nil {
+			return fmt.Errorf("header %q: value must be an object", name)
+		}
+		if err := header.Validate(ctx); err != nil {
+			return fmt.Errorf("header %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
 type Header struct {
 	Parameter
 }
@@ -256,6 +791,14 @@ func (header *Header) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, header)
 }
 
+// Validate returns an error if Header does not comply with the Swagger 2.0 spec.
+func (header *Header) Validate(ctx context.Context) error {
+	if err := validateSimpleType(header.Type, header.Items, false); err != nil {
+		return err
+	}
+	return validateCollectionFormat(header.CollectionFormat, header.Type, false)
+}
+
 type SecurityRequirements []map[string][]string
 
 type SecurityScheme struct {
@@ -281,3 +824,36 @@ func (securityScheme *SecurityScheme) MarshalJSON() ([]byte, error) {
 func (securityScheme *SecurityScheme) UnmarshalJSON(data []byte) error {
 	return jsoninfo.UnmarshalStrictStruct(data, securityScheme)
 }
+
+// Validate returns an error if SecurityScheme does not comply with the Swagger 2.0 spec.
+func (securityScheme *SecurityScheme) Validate(ctx context.Context) error {
+	switch securityScheme.Type {
+	case "basic":
+	case "apiKey":
+		if securityScheme.Name == "" {
+			return errors.New("security scheme of type apiKey should have 'name'")
+		}
+		if in := securityScheme.In; in != "query" && in != "header" {
+			return fmt.Errorf("security scheme of type apiKey should have 'in' query or header, got %q", in)
+		}
+	case "oauth2":
+		flow := securityScheme.Flow
+		switch flow {
+		case "implicit", "password", "application", "accessCode":
+		default:
+			return fmt.Errorf("security scheme of type oauth2 has unsupported 'flow' %q", flow)
+		}
+		if (flow == "implicit" || flow == "accessCode") && securityScheme.AuthorizationURL == "" {
+			return fmt.Errorf("security scheme with flow %s should have 'authorizationUrl'", flow)
+		}
+		if flow != "implicit" && securityScheme.TokenURL == "" {
+			return fmt.Errorf("security scheme with flow %s should have 'tokenUrl'", flow)
+		}
+		if securityScheme.Scopes == nil {
+			return errors.New("security scheme of type oauth2 should have 'scopes'")
+		}
+	default:
+		return fmt.Errorf("security scheme 'type' can't be %q", securityScheme.Type)
+	}
+	return nil
+}
@@ -1,13 +1,17 @@
 package openapi2_test
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"reflect"
+	"testing"
 
 	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/require"
 )
 
 func Example() {
@@ -50,3 +54,140 @@ func Example() {
 
 	// Output:
 }
+
+func TestValidate(t *testing.T) {
+	input, err := ioutil.ReadFile("testdata/swagger.json")
+	require.NoError(t, err)
+	load := func() *openapi2.T {
+		var doc openapi2.T
+		require.NoError(t, json.Unmarshal(input, &doc))
+		return &doc
+	}
+	require.NoError(t, load().Validate(context.Background()))
+
+	for _, tc := range []struct {
+		name   string
+		change func(doc *openapi2.T)
+		err    string
+	}{
+		{
+			"swagger version",
+			func(doc *openapi2.T) { doc.Swagger = "3.0" },
+			`value of swagger must be "2.0", got "3.0"`,
+		},
+		{
+			"info version",
+			func(doc *openapi2.T) { doc.Info.Version = "" },
+			`invalid info: value of version must be a non-empty string`,
+		},
+		{
+			"base path",
+			func(doc *openapi2.T) { doc.BasePath = "v2" },
+			`invalid basePath: "v2" does not start with a forward slash (/)`,
+		},
+		{
+			"path parameter not required",
+			func(doc *openapi2.T) { doc.Paths["/pet/{petId}"].Get.Parameters[0].Required = false },
+			`invalid paths: path "/pet/{petId}": operation GET: parameter #0: path parameter "petId" must be required`,
+		},
+		{
+			"missing path parameter",
+			func(doc *openapi2.T) { doc.Paths["/pet/{petId}"].Get.Parameters[0].Name = "id" },
+			`invalid paths: path "/pet/{petId}": operation GET: path parameter "petId" is not defined`,
+		},
+		{
+			"file outside formData",
+			func(doc *openapi2.T) { doc.Paths["/pet/{petId}"].Get.Parameters[0].Type = "file" },
+			`invalid paths: path "/pet/{petId}": operation GET: parameter #0: path parameter "petId": type file is only allowed for formData parameters`,
+		},
+		{
+			"collectionFormat multi in header",
+			func(doc *openapi2.T) {
+				doc.Paths["/pet/{petId}"].Delete.Parameters[0].Type = "array"
+				doc.Paths["/pet/{petId}"].Delete.Parameters[0].Items = openapi3.NewStringSchema().NewRef()
+				doc.Paths["/pet/{petId}"].Delete.Parameters[0].CollectionFormat = "multi"
+			},
+			`invalid paths: path "/pet/{petId}": operation DELETE: parameter #0: header parameter "api_key": collectionFormat multi is only allowed for query and formData parameters`,
+		},
+		{
+			"collectionFormat without array",
+			func(doc *openapi2.T) { doc.Paths["/pet/{petId}"].Get.Parameters[0].CollectionFormat = "csv" },
+			`invalid paths: path "/pet/{petId}": operation GET: parameter #0: path parameter "petId": collectionFormat "csv" requires type array`,
+		},
+		{
+			"two body parameters",
+			func(doc *openapi2.T) {
+				operation := doc.Paths["/pet"].Post
+				body := *operation.Parameters[0]
+				body.Name = "other"
+				operation.Parameters = append(operation.Parameters, &body)
+			},
+			`invalid paths: path "/pet": operation POST: body parameters "body" and "other" are defined, but an operation can have only one`,
+		},
+		{
+			"duplicate parameter",
+			func(doc *openapi2.T) {
+				operation := doc.Paths["/pet/{petId}"].Get
+				operation.Parameters = append(operation.Parameters, operation.Parameters[0])
+			},
+			`invalid paths: path "/pet/{petId}": operation GET: path parameter "petId" is defined more than once`,
+		},
+		{
+			"undefined definition",
+			func(doc *openapi2.T) { doc.Paths["/pet"].Post.Parameters[0].Schema.Ref = "#/definitions/Animal" },
+			`invalid paths: path "/pet": operation POST: parameter #0: parameter "body" schema is invalid: definition "Animal" referenced by "#/definitions/Animal" is not defined`,
+		},
+		{
+			"undefined definition in definitions",
+			func(doc *openapi2.T) { doc.Definitions["Pet"].Value.Properties["category"].Ref = "#/definitions/Kind" },
+			`invalid definitions: definition "Pet": property "category": definition "Kind" referenced by "#/definitions/Kind" is not defined`,
+		},
+		{
+			"undefined parameter",
+			func(doc *openapi2.T) {
+				doc.Paths["/pet"].Post.Parameters[0] = &openapi2.Parameter{Ref: "#/parameters/Pet"}
+			},
+			`invalid paths: path "/pet": operation POST: parameter #0: parameter "Pet" referenced by "#/parameters/Pet" is not defined`,
+		},
+		{
+			"response without description",
+			func(doc *openapi2.T) { doc.Paths["/pet"].Post.Responses["405"].Description = "" },
+			`invalid paths: path "/pet": operation POST: response "405": value of description must be a non-empty string`,
+		},
+		{
+			"invalid response code",
+			func(doc *openapi2.T) {
+				doc.Paths["/pet"].Post.Responses["2XX"] = &openapi2.Response{Description: "OK"}
+			},
+			`invalid paths: path "/pet": operation POST: response code "2XX" must be a status code or default`,
+		},
+		{
+			"undefined security definition",
+			func(doc *openapi2.T) { (*doc.Paths["/pet"].Post.Security)[0]["oauth"] = nil },
+			`invalid paths: path "/pet": operation POST: invalid security: security definition "oauth" is not defined`,
+		},
+		{
+			"undefined scope",
+			func(doc *openapi2.T) {
+				(*doc.Paths["/pet"].Post.Security)[0]["petstore_auth"] = []string{"delete:pets"}
+			},
+			`invalid paths: path "/pet": operation POST: invalid security: scope "delete:pets" is not defined by security definition "petstore_auth"`,
+		},
+		{
+			"oauth2 without authorizationUrl",
+			func(doc *openapi2.T) { doc.SecurityDefinitions["petstore_auth"].AuthorizationURL = "" },
+			`invalid securityDefinitions: security scheme "petstore_auth": security scheme with flow implicit should have 'authorizationUrl'`,
+		},
+		{
+			"duplicate operationId",
+			func(doc *openapi2.T) { doc.Paths["/pet"].Put.OperationID = "addPet" },
+			`invalid paths: operations POST /pet and PUT /pet have the same operationId "addPet"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := load()
+			tc.change(doc)
+			require.EqualError(t, doc.Validate(context.Background()), tc.err)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi2"
@@ -14,7 +15,14 @@ import (
 
 // ToV3 converts an OpenAPIv2 spec to an OpenAPIv3 spec
 func ToV3(doc2 *openapi2.T) (*openapi3.T, error) {
+	return toV3(doc2, reportAt{})
+}
+
+func toV3(doc2 *openapi2.T, at reportAt) (*openapi3.T, error) {
 	stripNonCustomExtensions(doc2.Extensions)
+	if len(doc2.Produces) != 0 {
+		at.child("produces").add("produces is dropped: response schemas are converted to application/json content")
+	}
 
 	doc3 := &openapi3.T{
 		OpenAPI:        "3.0.3",
@@ -53,7 +61,7 @@ func ToV3(doc2 *openapi2.T) (*openapi3.T, error) {
 		doc3.Components.Parameters = make(map[string]*openapi3.ParameterRef)
 		doc3.Components.RequestBodies = make(map[string]*openapi3.RequestBodyRef)
 		for k, parameter := range parameters {
-			v3Parameter, v3RequestBody, v3SchemaMap, err := ToV3Parameter(&doc3.Components, parameter, doc2.Consumes)
+			v3Parameter, v3RequestBody, v3SchemaMap, err := toV3Parameter(&doc3.Components, parameter, doc2.Consumes, at.child("parameters", k))
 			switch {
 			case err != nil:
 				return nil, err
@@ -72,7 +80,7 @@ func ToV3(doc2 *openapi2.T) (*openapi3.T, error) {
 	if paths := doc2.Paths; len(paths) != 0 {
 		doc3Paths := make(map[string]*openapi3.PathItem, len(paths))
 		for path, pathItem := range paths {
-			r, err := ToV3PathItem(doc2, &doc3.Components, pathItem, doc2.Consumes)
+			r, err := toV3PathItem(doc2, &doc3.Components, pathItem, doc2.Consumes, at.child("paths", path))
 			if err != nil {
 				return nil, err
 			}
@@ -84,7 +92,7 @@ func ToV3(doc2 *openapi2.T) (*openapi3.T, error) {
 	if responses := doc2.Responses; len(responses) != 0 {
 		doc3.Components.Responses = make(map[string]*openapi3.ResponseRef, len(responses))
 		for k, response := range responses {
-			r, err := ToV3Response(response)
+			r, err := toV3Response(response, at.child("responses", k))
 			if err != nil {
 				return nil, err
 			}
@@ -119,19 +127,23 @@ func ToV3(doc2 *openapi2.T) (*openapi3.T, error) {
 }
 
 func ToV3PathItem(doc2 *openapi2.T, components *openapi3.Components, pathItem *openapi2.PathItem, consumes []string) (*openapi3.PathItem, error) {
+	return toV3PathItem(doc2, components, pathItem, consumes, reportAt{})
+}
+
+func toV3PathItem(doc2 *openapi2.T, components *openapi3.Components, pathItem *openapi2.PathItem, consumes []string, at reportAt) (*openapi3.PathItem, error) {
 	stripNonCustomExtensions(pathItem.Extensions)
 	doc3 := &openapi3.PathItem{
 		ExtensionProps: pathItem.ExtensionProps,
 	}
 	for method, operation := range pathItem.Operations() {
-		doc3Operation, err := ToV3Operation(doc2, components, pathItem, operation, consumes)
+		doc3Operation, err := toV3Operation(doc2, components, pathItem, operation, consumes, at.child(strings.ToLower(method)))
 		if err != nil {
 			return nil, err
 		}
 		doc3.SetOperation(method, doc3Operation)
 	}
-	for _, parameter := range pathItem.Parameters {
-		v3Parameter, v3RequestBody, v3Schema, err := ToV3Parameter(components, parameter, consumes)
+	for i, parameter := range pathItem.Parameters {
+		v3Parameter, v3RequestBody, v3Schema, err := toV3Parameter(components, parameter, consumes, at.child("parameters", strconv.Itoa(i)))
 		switch {
 		case err != nil:
 			return nil, err
@@ -147,10 +159,23 @@ func ToV3PathItem(doc2 *openapi2.T, components *openapi3.Components, pathItem *o
 }
 
 func ToV3Operation(doc2 *openapi2.T, components *openapi3.Components, pathItem *openapi2.PathItem, operation *openapi2.Operation, consumes []string) (*openapi3.Operation, error) {
+	return toV3Operation(doc2, components, pathItem, operation, consumes, reportAt{})
+}
+
+func toV3Operation(doc2 *openapi2.T, components *openapi3.Components, pathItem *openapi2.PathItem, operation *openapi2.Operation, consumes []string, at reportAt) (*openapi3.Operation, error) {
 	if operation == nil {
 		return nil, nil
 	}
 	stripNonCustomExtensions(operation.Extensions)
+	if len(operation.Produces) != 0 {
+		at.child("produces").add("produces is dropped: response schemas are converted to application/json content")
+	}
+	if len(operation.Schemes) != 0 {
+		at.child("schemes").add("schemes of an operation are dropped")
+	}
+	if operation.ExternalDocs != nil {
+		at.child("externalDocs").add("externalDocs of an operation are dropped")
+	}
 	doc3 := &openapi3.Operation{
 		OperationID:    operation.OperationID,
 		Summary:        operation.Summary,
@@ -169,8 +194,8 @@ func ToV3Operation(doc2 *openapi2.T, components *openapi3.Components, pathItem *
 
 	var reqBodies []*openapi3.RequestBodyRef
 	formDataSchemas := make(map[string]*openapi3.SchemaRef)
-	for _, parameter := range operation.Parameters {
-		v3Parameter, v3RequestBody, v3SchemaMap, err := ToV3Parameter(components, parameter, consumes)
+	for i, parameter := range operation.Parameters {
+		v3Parameter, v3RequestBody, v3SchemaMap, err := toV3Parameter(components, parameter, consumes, at.child("parameters", strconv.Itoa(i)))
 		switch {
 		case err != nil:
 			return nil, err
@@ -192,7 +217,7 @@ func ToV3Operation(doc2 *openapi2.T, components *openapi3.Components, pathItem *
 	if responses := operation.Responses; responses != nil {
 		doc3Responses := make(openapi3.Responses, len(responses))
 		for k, response := range responses {
-			doc3, err := ToV3Response(response)
+			doc3, err := toV3Response(response, at.child("responses", k))
 			if err != nil {
 				return nil, err
 			}
@@ -211,6 +236,10 @@ func getParameterNameFromOldRef(ref string) string {
 }
 
 func ToV3Parameter(components *openapi3.Components, parameter *openapi2.Parameter, consumes []string) (*openapi3.ParameterRef, *openapi3.RequestBodyRef, map[string]*openapi3.SchemaRef, error) {
+	return toV3Parameter(components, parameter, consumes, reportAt{})
+}
+
+func toV3Parameter(components *openapi3.Components, parameter *openapi2.Parameter, consumes []string, at reportAt) (*openapi3.ParameterRef, *openapi3.RequestBodyRef, map[string]*openapi3.SchemaRef, error) {
 	if ref := parameter.Ref; ref != "" {
 		if strings.HasPrefix(ref, "#/parameters/") {
 			name := getParameterNameFromOldRef(ref)
@@ -252,6 +281,8 @@ func ToV3Parameter(components *openapi3.Components, parameter *openapi2.Paramete
 		return nil, &openapi3.RequestBodyRef{Value: result}, nil, nil
 
 	case "formData":
+		at.collectionFormat(parameter.In, parameter)
+		at.allowEmptyValue(parameter)
 		format, typ := parameter.Format, parameter.Type
 		if typ == "file" {
 			format, typ = "binary", "string"
@@ -293,6 +324,8 @@ func ToV3Parameter(components *openapi3.Components, parameter *openapi2.Paramete
 		return nil, nil, schemaRefMap, nil
 
 	default:
+		at.collectionFormat(parameter.In, parameter)
+		at.allowEmptyValue(parameter)
 		required := parameter.Required
 		if parameter.In == openapi3.ParameterInPath {
 			required = true
@@ -413,10 +446,17 @@ func onlyOneReqBodyParam(bodies []*openapi3.RequestBodyRef, formDataSchemas map[
 }
 
 func ToV3Response(response *openapi2.Response) (*openapi3.ResponseRef, error) {
+	return toV3Response(response, reportAt{})
+}
+
+func toV3Response(response *openapi2.Response, at reportAt) (*openapi3.ResponseRef, error) {
 	if ref := response.Ref; ref != "" {
 		return &openapi3.ResponseRef{Ref: ToV3Ref(ref)}, nil
 	}
 	stripNonCustomExtensions(response.Extensions)
+	if len(response.Examples) != 0 {
+		at.child("examples").add("examples of a response are dropped")
+	}
 	result := &openapi3.Response{
 		Description:    &response.Description,
 		ExtensionProps: response.ExtensionProps,
@@ -425,12 +465,16 @@ func ToV3Response(response *openapi2.Response) (*openapi3.ResponseRef, error) {
 		result.WithJSONSchemaRef(ToV3SchemaRef(schemaRef))
 	}
 	if headers := response.Headers; len(headers) > 0 {
-		result.Headers = ToV3Headers(headers)
+		result.Headers = toV3Headers(headers, at.child("headers"))
 	}
 	return &openapi3.ResponseRef{Value: result}, nil
 }
 
 func ToV3Headers(defs map[string]*openapi2.Header) openapi3.Headers {
+	return toV3Headers(defs, reportAt{})
+}
+
+func toV3Headers(defs map[string]*openapi2.Header, at reportAt) openapi3.Headers {
 	headers := make(openapi3.Headers, len(defs))
 	for name, header := range defs {
 		header.In = ""
@@ -438,6 +482,7 @@ func ToV3Headers(defs map[string]*openapi2.Header) openapi3.Headers {
 		if ref := header.Ref; ref != "" {
 			headers[name] = &openapi3.HeaderRef{Ref: ToV3Ref(ref)}
 		} else {
+			at.child(name).collectionFormat("header", &header.Parameter)
 			parameter, _, _, _ := ToV3Parameter(nil, &header.Parameter, nil)
 			headers[name] = &openapi3.HeaderRef{Value: &openapi3.Header{
 				Parameter: *parameter.Value,
@@ -565,12 +610,29 @@ func ToV3SecurityScheme(securityScheme *openapi2.SecurityScheme) (*openapi3.Secu
 
 // FromV3 converts an OpenAPIv3 spec to an OpenAPIv2 spec
 func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
-	doc2Responses, err := FromV3Responses(doc3.Components.Responses, &doc3.Components)
+	return fromV3(doc3, reportAt{})
+}
+
+func fromV3(doc3 *openapi3.T, at reportAt) (*openapi2.T, error) {
+	components := at.child("components")
+	doc2Responses, err := fromV3Responses(doc3.Components.Responses, &doc3.Components, components.child("responses"))
 	if err != nil {
 		return nil, err
 	}
 	stripNonCustomExtensions(doc3.Extensions)
-	schemas, parameters := FromV3Schemas(doc3.Components.Schemas, &doc3.Components)
+	schemas, parameters := fromV3Schemas(doc3.Components.Schemas, &doc3.Components, components.child("schemas"))
+	if len(doc3.Components.Headers) != 0 {
+		components.child("headers").add("header components are dropped")
+	}
+	if len(doc3.Components.Examples) != 0 {
+		components.child("examples").add("example components are dropped")
+	}
+	if len(doc3.Components.Links) != 0 {
+		components.child("links").add("links have no Swagger 2.0 equivalent and are dropped")
+	}
+	if len(doc3.Components.Callbacks) != 0 {
+		components.child("callbacks").add("callbacks have no Swagger 2.0 equivalent and are dropped")
+	}
 	doc2 := &openapi2.T{
 		Swagger:        "2.0",
 		Info:           *doc3.Info,
@@ -586,6 +648,13 @@ func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
 	isHTTP := false
 	servers := doc3.Servers
 	for i, server := range servers {
+		if server == nil {
+			continue
+		}
+		at := at.child("servers", strconv.Itoa(i))
+		if len(server.Variables) != 0 {
+			at.child("variables").add("server variables are dropped")
+		}
 		parsedURL, err := url.Parse(server.URL)
 		if err == nil {
 			// See which schemes seem to be supported
@@ -598,6 +667,8 @@ func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
 			if i == 0 {
 				doc2.Host = parsedURL.Host
 				doc2.BasePath = parsedURL.Path
+			} else if parsedURL.Host != doc2.Host || parsedURL.Path != doc2.BasePath {
+				at.add("only the first server provides the host and basePath")
 			}
 		}
 	}
@@ -611,6 +682,16 @@ func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
 		if pathItem == nil {
 			continue
 		}
+		at := at.child("paths", path)
+		if pathItem.Summary != "" {
+			at.child("summary").add("summary of a path item is dropped")
+		}
+		if pathItem.Description != "" {
+			at.child("description").add("description of a path item is dropped")
+		}
+		if len(pathItem.Servers) != 0 {
+			at.child("servers").add("servers of a path item are dropped")
+		}
 		doc2.AddOperation(path, "GET", nil)
 		stripNonCustomExtensions(pathItem.Extensions)
 		addPathExtensions(doc2, path, pathItem.ExtensionProps)
@@ -618,15 +699,15 @@ func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
 			if operation == nil {
 				continue
 			}
-			doc2Operation, err := FromV3Operation(doc3, operation)
+			doc2Operation, err := fromV3Operation(doc3, operation, at.child(strings.ToLower(method)))
 			if err != nil {
 				return nil, err
 			}
 			doc2.AddOperation(path, method, doc2Operation)
 		}
 		params := openapi2.Parameters{}
-		for _, param := range pathItem.Parameters {
-			p, err := FromV3Parameter(param, &doc3.Components)
+		for i, param := range pathItem.Parameters {
+			p, err := fromV3Parameter(param, &doc3.Components, at.child("parameters", strconv.Itoa(i)), "parameter")
 			if err != nil {
 				return nil, err
 			}
@@ -637,13 +718,13 @@ func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
 	}
 
 	for name, param := range doc3.Components.Parameters {
-		if doc2.Parameters[name], err = FromV3Parameter(param, &doc3.Components); err != nil {
+		if doc2.Parameters[name], err = fromV3Parameter(param, &doc3.Components, components.child("parameters", name), "parameter"); err != nil {
 			return nil, err
 		}
 	}
 
 	for name, requestBodyRef := range doc3.Components.RequestBodies {
-		bodyOrRefParameters, formDataParameters, consumes, err := fromV3RequestBodies(name, requestBodyRef, &doc3.Components)
+		bodyOrRefParameters, formDataParameters, consumes, err := fromV3RequestBodies(name, requestBodyRef, &doc3.Components, components.child("requestBodies", name))
 		if err != nil {
 			return nil, err
 		}
@@ -665,7 +746,7 @@ func FromV3(doc3 *openapi3.T) (*openapi2.T, error) {
 	if m := doc3.Components.SecuritySchemes; m != nil {
 		doc2SecuritySchemes := make(map[string]*openapi2.SecurityScheme)
 		for id, securityScheme := range m {
-			v, err := FromV3SecurityScheme(doc3, securityScheme)
+			v, err := fromV3SecurityScheme(doc3, securityScheme, components.child("securitySchemes", id))
 			if err != nil {
 				return nil, err
 			}
@@ -687,7 +768,7 @@ func consumesToArray(consumes map[string]struct{}) []string {
 	return consumesArr
 }
 
-func fromV3RequestBodies(name string, requestBodyRef *openapi3.RequestBodyRef, components *openapi3.Components) (
+func fromV3RequestBodies(name string, requestBodyRef *openapi3.RequestBodyRef, components *openapi3.Components, at reportAt) (
 	bodyOrRefParameters openapi2.Parameters,
 	formParameters openapi2.Parameters,
 	consumes map[string]struct{},
@@ -700,13 +781,20 @@ func fromV3RequestBodies(name string, requestBodyRef *openapi3.RequestBodyRef, c
 
 	//Only select one formData or request body for an individual requestBody as OpenAPI 2 does not support multiples
 	if requestBodyRef.Value != nil {
-		for contentType, mediaType := range requestBodyRef.Value.Content {
+		content := requestBodyRef.Value.Content
+		if len(content) > 1 {
+			at.child("content").add("only one of the %d media types of the request body is converted", len(content))
+		}
+		for contentType, mediaType := range content {
 			if consumes == nil {
 				consumes = make(map[string]struct{})
 			}
 			consumes[contentType] = struct{}{}
+			at := at.child("content", contentType)
+			at.mediaType(mediaType)
 			if contentType == "application/x-www-form-urlencoded" || contentType == "multipart/form-data" {
 				formParameters = FromV3RequestBodyFormData(mediaType)
+				at.child("schema").schemaRef(mediaType.Schema)
 				continue
 			}
 
@@ -716,7 +804,7 @@ func fromV3RequestBodies(name string, requestBodyRef *openapi3.RequestBodyRef, c
 			}
 
 			var r *openapi2.Parameter
-			if r, err = FromV3RequestBody(paramName, requestBodyRef, mediaType, components); err != nil {
+			if r, err = fromV3RequestBody(paramName, requestBodyRef, mediaType, components, at.child("schema")); err != nil {
 				return
 			}
 
@@ -727,10 +815,14 @@ func fromV3RequestBodies(name string, requestBodyRef *openapi3.RequestBodyRef, c
 }
 
 func FromV3Schemas(schemas map[string]*openapi3.SchemaRef, components *openapi3.Components) (map[string]*openapi3.SchemaRef, map[string]*openapi2.Parameter) {
+	return fromV3Schemas(schemas, components, reportAt{})
+}
+
+func fromV3Schemas(schemas map[string]*openapi3.SchemaRef, components *openapi3.Components, at reportAt) (map[string]*openapi3.SchemaRef, map[string]*openapi2.Parameter) {
 	v2Defs := make(map[string]*openapi3.SchemaRef)
 	v2Params := make(map[string]*openapi2.Parameter)
 	for name, schema := range schemas {
-		schemaConv, parameterConv := FromV3SchemaRef(schema, components)
+		schemaConv, parameterConv := fromV3SchemaRef(schema, components, at.child(name))
 		if schemaConv != nil {
 			v2Defs[name] = schemaConv
 		} else if parameterConv != nil {
@@ -744,6 +836,10 @@ func FromV3Schemas(schemas map[string]*openapi3.SchemaRef, components *openapi3.
 }
 
 func FromV3SchemaRef(schema *openapi3.SchemaRef, components *openapi3.Components) (*openapi3.SchemaRef, *openapi2.Parameter) {
+	return fromV3SchemaRef(schema, components, reportAt{})
+}
+
+func fromV3SchemaRef(schema *openapi3.SchemaRef, components *openapi3.Components, at reportAt) (*openapi3.SchemaRef, *openapi2.Parameter) {
 	if ref := schema.Ref; ref != "" {
 		name := getParameterNameFromNewRef(ref)
 		if val, ok := components.Schemas[name]; ok {
@@ -796,8 +892,9 @@ func FromV3SchemaRef(schema *openapi3.SchemaRef, components *openapi3.Components
 			}
 		}
 	}
+	at.schema(schema.Value)
 	if v := schema.Value.Items; v != nil {
-		schema.Value.Items, _ = FromV3SchemaRef(v, components)
+		schema.Value.Items, _ = fromV3SchemaRef(v, components, at.child("items"))
 	}
 	keys := make([]string, 0, len(schema.Value.Properties))
 	for k := range schema.Value.Properties {
@@ -805,14 +902,22 @@ func FromV3SchemaRef(schema *openapi3.SchemaRef, components *openapi3.Components
 	}
 	sort.Strings(keys)
 	for _, key := range keys {
-		schema.Value.Properties[key], _ = FromV3SchemaRef(schema.Value.Properties[key], components)
+		schema.Value.Properties[key], _ = fromV3SchemaRef(schema.Value.Properties[key], components, at.child("properties", key))
 	}
 	if v := schema.Value.AdditionalProperties; v != nil {
-		schema.Value.AdditionalProperties, _ = FromV3SchemaRef(v, components)
+		schema.Value.AdditionalProperties, _ = fromV3SchemaRef(v, components, at.child("additionalProperties"))
 	}
 	for i, v := range schema.Value.AllOf {
-		schema.Value.AllOf[i], _ = FromV3SchemaRef(v, components)
+		schema.Value.AllOf[i], _ = fromV3SchemaRef(v, components, at.child("allOf", strconv.Itoa(i)))
+	}
+	// Kept as is, for lack of Swagger 2.0 equivalents, but reported
+	for i, v := range schema.Value.OneOf {
+		at.child("oneOf", strconv.Itoa(i)).schemaRef(v)
+	}
+	for i, v := range schema.Value.AnyOf {
+		at.child("anyOf", strconv.Itoa(i)).schemaRef(v)
 	}
+	at.child("not").schemaRef(schema.Value.Not)
 	return schema, nil
 }
 
@@ -914,10 +1019,26 @@ func FromV3RequestBodyFormData(mediaType *openapi3.MediaType) openapi2.Parameter
 }
 
 func FromV3Operation(doc3 *openapi3.T, operation *openapi3.Operation) (*openapi2.Operation, error) {
+	return fromV3Operation(doc3, operation, reportAt{})
+}
+
+func fromV3Operation(doc3 *openapi3.T, operation *openapi3.Operation, at reportAt) (*openapi2.Operation, error) {
 	if operation == nil {
 		return nil, nil
 	}
 	stripNonCustomExtensions(operation.Extensions)
+	if len(operation.Callbacks) != 0 {
+		at.child("callbacks").add("callbacks have no Swagger 2.0 equivalent and are dropped")
+	}
+	if operation.Servers != nil && len(*operation.Servers) != 0 {
+		at.child("servers").add("servers of an operation are dropped")
+	}
+	if operation.ExternalDocs != nil {
+		at.child("externalDocs").add("externalDocs of an operation are dropped")
+	}
+	if operation.Deprecated {
+		at.child("deprecated").add("deprecated is dropped")
+	}
 	result := &openapi2.Operation{
 		OperationID:    operation.OperationID,
 		Summary:        operation.Summary,
@@ -929,8 +1050,8 @@ func FromV3Operation(doc3 *openapi3.T, operation *openapi3.Operation) (*openapi2
 		resultSecurity := FromV3SecurityRequirements(*v)
 		result.Security = &resultSecurity
 	}
-	for _, parameter := range operation.Parameters {
-		r, err := FromV3Parameter(parameter, &doc3.Components)
+	for i, parameter := range operation.Parameters {
+		r, err := fromV3Parameter(parameter, &doc3.Components, at.child("parameters", strconv.Itoa(i)), "parameter")
 		if err != nil {
 			return nil, err
 		}
@@ -943,7 +1064,7 @@ func FromV3Operation(doc3 *openapi3.T, operation *openapi3.Operation) (*openapi2
 			return nil, errors.New("could not find a name for request body")
 		}
 
-		bodyOrRefParameters, formDataParameters, consumes, err := fromV3RequestBodies(name, v, &doc3.Components)
+		bodyOrRefParameters, formDataParameters, consumes, err := fromV3RequestBodies(name, v, &doc3.Components, at.child("requestBody"))
 		if err != nil {
 			return nil, err
 		}
@@ -964,7 +1085,7 @@ func FromV3Operation(doc3 *openapi3.T, operation *openapi3.Operation) (*openapi2
 	sort.Sort(result.Parameters)
 
 	if responses := operation.Responses; responses != nil {
-		resultResponses, err := FromV3Responses(responses, &doc3.Components)
+		resultResponses, err := fromV3Responses(responses, &doc3.Components, at.child("responses"))
 		if err != nil {
 			return nil, err
 		}
@@ -974,6 +1095,11 @@ func FromV3Operation(doc3 *openapi3.T, operation *openapi3.Operation) (*openapi2
 }
 
 func FromV3RequestBody(name string, requestBodyRef *openapi3.RequestBodyRef, mediaType *openapi3.MediaType, components *openapi3.Components) (*openapi2.Parameter, error) {
+	return fromV3RequestBody(name, requestBodyRef, mediaType, components, reportAt{})
+}
+
+// fromV3RequestBody converts a request body, reporting the issues of the schema of its media type at the given location.
+func fromV3RequestBody(name string, requestBodyRef *openapi3.RequestBodyRef, mediaType *openapi3.MediaType, components *openapi3.Components, at reportAt) (*openapi2.Parameter, error) {
 	requestBody := requestBodyRef.Value
 
 	stripNonCustomExtensions(requestBody.Extensions)
@@ -986,12 +1112,17 @@ func FromV3RequestBody(name string, requestBodyRef *openapi3.RequestBodyRef, med
 	}
 
 	if mediaType != nil {
-		result.Schema, _ = FromV3SchemaRef(mediaType.Schema, components)
+		result.Schema, _ = fromV3SchemaRef(mediaType.Schema, components, at)
 	}
 	return result, nil
 }
 
 func FromV3Parameter(ref *openapi3.ParameterRef, components *openapi3.Components) (*openapi2.Parameter, error) {
+	return fromV3Parameter(ref, components, reportAt{}, "parameter")
+}
+
+// fromV3Parameter converts a parameter, or the parameter of a header given the "header" kind.
+func fromV3Parameter(ref *openapi3.ParameterRef, components *openapi3.Components, at reportAt, kind string) (*openapi2.Parameter, error) {
 	if ref := ref.Ref; ref != "" {
 		return &openapi2.Parameter{Ref: FromV3Ref(ref)}, nil
 	}
@@ -1000,6 +1131,7 @@ func FromV3Parameter(ref *openapi3.ParameterRef, components *openapi3.Components
 		return nil, nil
 	}
 	stripNonCustomExtensions(parameter.Extensions)
+	at.parameter(parameter, kind)
 	result := &openapi2.Parameter{
 		Description:    parameter.Description,
 		In:             parameter.In,
@@ -1008,7 +1140,7 @@ func FromV3Parameter(ref *openapi3.ParameterRef, components *openapi3.Components
 		ExtensionProps: parameter.ExtensionProps,
 	}
 	if schemaRef := parameter.Schema; schemaRef != nil {
-		schemaRef, _ = FromV3SchemaRef(schemaRef, components)
+		schemaRef, _ = fromV3SchemaRef(schemaRef, components, at.child("schema"))
 		if ref := schemaRef.Ref; ref != "" {
 			result.Schema = &openapi3.SchemaRef{Ref: FromV3Ref(ref)}
 			return result, nil
@@ -1037,9 +1169,13 @@ func FromV3Parameter(ref *openapi3.ParameterRef, components *openapi3.Components
 }
 
 func FromV3Responses(responses map[string]*openapi3.ResponseRef, components *openapi3.Components) (map[string]*openapi2.Response, error) {
+	return fromV3Responses(responses, components, reportAt{})
+}
+
+func fromV3Responses(responses map[string]*openapi3.ResponseRef, components *openapi3.Components, at reportAt) (map[string]*openapi2.Response, error) {
 	v2Responses := make(map[string]*openapi2.Response, len(responses))
 	for k, response := range responses {
-		r, err := FromV3Response(response, components)
+		r, err := fromV3Response(response, components, at.child(k))
 		if err != nil {
 			return nil, err
 		}
@@ -1049,6 +1185,10 @@ func FromV3Responses(responses map[string]*openapi3.ResponseRef, components *ope
 }
 
 func FromV3Response(ref *openapi3.ResponseRef, components *openapi3.Components) (*openapi2.Response, error) {
+	return fromV3Response(ref, components, reportAt{})
+}
+
+func fromV3Response(ref *openapi3.ResponseRef, components *openapi3.Components, at reportAt) (*openapi2.Response, error) {
 	if ref := ref.Ref; ref != "" {
 		return &openapi2.Response{Ref: FromV3Ref(ref)}, nil
 	}
@@ -1067,24 +1207,38 @@ func FromV3Response(ref *openapi3.ResponseRef, components *openapi3.Components)
 		ExtensionProps: response.ExtensionProps,
 	}
 	if content := response.Content; content != nil {
+		for contentType := range content {
+			if contentType != "application/json" {
+				at.child("content", contentType).add("only application/json content of a response is converted")
+			}
+		}
 		if ct := content["application/json"]; ct != nil {
-			result.Schema, _ = FromV3SchemaRef(ct.Schema, components)
+			at := at.child("content", "application/json")
+			at.mediaType(ct)
+			result.Schema, _ = fromV3SchemaRef(ct.Schema, components, at.child("schema"))
 		}
 	}
 	if headers := response.Headers; len(headers) > 0 {
 		var err error
-		if result.Headers, err = FromV3Headers(headers, components); err != nil {
+		if result.Headers, err = fromV3Headers(headers, components, at.child("headers")); err != nil {
 			return nil, err
 		}
 	}
+	if len(response.Links) != 0 {
+		at.child("links").add("links have no Swagger 2.0 equivalent and are dropped")
+	}
 	return result, nil
 }
 
 func FromV3Headers(defs openapi3.Headers, components *openapi3.Components) (map[string]*openapi2.Header, error) {
+	return fromV3Headers(defs, components, reportAt{})
+}
+
+func fromV3Headers(defs openapi3.Headers, components *openapi3.Components, at reportAt) (map[string]*openapi2.Header, error) {
 	headers := make(map[string]*openapi2.Header, len(defs))
 	for name, header := range defs {
 		ref := openapi3.ParameterRef{Ref: header.Ref, Value: &header.Value.Parameter}
-		parameter, err := FromV3Parameter(&ref, components)
+		parameter, err := fromV3Parameter(&ref, components, at.child(name), "header")
 		if err != nil {
 			return nil, err
 		}
@@ -1096,6 +1250,10 @@ func FromV3Headers(defs openapi3.Headers, components *openapi3.Components) (map[
 }
 
 func FromV3SecurityScheme(doc3 *openapi3.T, ref *openapi3.SecuritySchemeRef) (*openapi2.SecurityScheme, error) {
+	return fromV3SecurityScheme(doc3, ref, reportAt{})
+}
+
+func fromV3SecurityScheme(doc3 *openapi3.T, ref *openapi3.SecuritySchemeRef, at reportAt) (*openapi2.SecurityScheme, error) {
 	securityScheme := ref.Value
 	if securityScheme == nil {
 		return nil, nil
@@ -1112,6 +1270,7 @@ func FromV3SecurityScheme(doc3 *openapi3.T, ref *openapi3.SecuritySchemeRef) (*o
 		case "basic":
 			result.Type = "basic"
 		default:
+			at.child("scheme").add("http %s authentication is rewritten as an apiKey in the Authorization header", securityScheme.Scheme)
 			result.Type = "apiKey"
 			result.In = "header"
 			result.Name = "Authorization"
@@ -1151,6 +1310,7 @@ func FromV3SecurityScheme(doc3 *openapi3.T, ref *openapi3.SecuritySchemeRef) (*o
 			default:
 				return nil, nil
 			}
+			at.oauthFlows(flows)
 
 			result.Scopes = make(map[string]string, len(flow.Scopes))
 			for scope, desc := range flow.Scopes {
@@ -857,3 +857,20 @@ const exampleRequestBodyV2 = `{
 	"swagger": "2.0"
 }
 `
+
+func TestConvOpenAPIV2ToV3WithExternalRefs(t *testing.T) {
+	loader := openapi2.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	doc2, err := loader.LoadFromFile("../openapi2/testdata/refs/swagger.yaml")
+	require.NoError(t, err)
+
+	doc3, err := ToV3(doc2)
+	require.NoError(t, err)
+	err = doc3.Validate(context.Background())
+	require.NoError(t, err)
+
+	schema := doc3.Paths["/users/{id}"].Get.Responses["200"].Value.Content.Get("application/json").Schema
+	require.Equal(t, "#/components/schemas/User", schema.Ref)
+	require.Same(t, doc3.Components.Schemas["User"].Value, schema.Value)
+	require.Equal(t, "#/components/parameters/UserID", doc3.Paths["/users/{id}"].Get.Parameters[0].Ref)
+}
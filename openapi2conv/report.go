@@ -0,0 +1,246 @@
+package openapi2conv
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/go-openapi/jsonpointer"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Report lists the constructs of a source document a conversion drops
+// or rewrites because the target version has no exact equivalent.
+type Report struct {
+	Issues []Issue
+}
+
+// Issue is a construct of the source document a conversion drops or approximates.
+type Issue struct {
+	// Pointer is the JSON pointer to the construct in the source document.
+	Pointer string
+	// Reason tells why the construct is not converted as is.
+	Reason string
+}
+
+// String returns the issue as pointer: reason.
+func (issue Issue) String() string {
+	return issue.Pointer + ": " + issue.Reason
+}
+
+// ToV3WithReport converts an OpenAPIv2 spec to an OpenAPIv3 spec like ToV3 does,
+// and reports the constructs of doc2 the conversion drops or approximates.
+func ToV3WithReport(doc2 *openapi2.T) (*openapi3.T, *Report, error) {
+	report := &Report{}
+	doc3, err := toV3(doc2, reportAt{report: report})
+	if err != nil {
+		return nil, nil, err
+	}
+	report.sort()
+	return doc3, report, nil
+}
+
+// FromV3WithReport converts an OpenAPIv3 spec to an OpenAPIv2 spec like FromV3 does,
+// and reports the constructs of doc3 the conversion drops or approximates.
+func FromV3WithReport(doc3 *openapi3.T) (*openapi2.T, *Report, error) {
+	report := &Report{}
+	doc2, err := fromV3(doc3, reportAt{report: report})
+	if err != nil {
+		return nil, nil, err
+	}
+	report.sort()
+	return doc2, report, nil
+}
+
+// sort orders the issues, which the conversion finds in map iteration order.
+func (report *Report) sort() {
+	sort.Slice(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if a.Pointer != b.Pointer {
+			return a.Pointer < b.Pointer
+		}
+		return a.Reason < b.Reason
+	})
+}
+
+// reportAt is the location in the source document the conversion is at.
+// The zero value reports nothing, for conversions without a report.
+type reportAt struct {
+	report  *Report
+	pointer string
+}
+
+// child returns the location of the construct at the given reference tokens.
+func (at reportAt) child(tokens ...string) reportAt {
+	if at.report == nil {
+		return at
+	}
+	for _, token := range tokens {
+		at.pointer += "/" + jsonpointer.Escape(token)
+	}
+	return at
+}
+
+func (at reportAt) add(format string, args ...interface{}) {
+	if at.report == nil {
+		return
+	}
+	at.report.Issues = append(at.report.Issues, Issue{Pointer: at.pointer, Reason: fmt.Sprintf(format, args...)})
+}
+
+// collectionFormat reports the collection format of an array parameter
+// that differs from the default style of its location in OpenAPI 3,
+// which the converted parameter has.
+func (at reportAt) collectionFormat(in string, parameter *openapi2.Parameter) {
+	if at.report == nil || parameter.Type != "array" {
+		return
+	}
+	collectionFormat := parameter.CollectionFormat
+	if collectionFormat == "" {
+		collectionFormat = "csv"
+	}
+	switch in {
+	case "path", "header":
+		if collectionFormat == "csv" {
+			return
+		}
+	case "query", "formData":
+		if collectionFormat == "multi" {
+			return
+		}
+	default:
+		return
+	}
+	if parameter.CollectionFormat == "" {
+		at.add("the default collectionFormat csv is dropped: the parameter is serialized with the default style of %s parameters", in)
+		return
+	}
+	at = at.child("collectionFormat")
+	if collectionFormat == "tsv" {
+		at.add("collectionFormat tsv has no OpenAPI 3 equivalent")
+		return
+	}
+	at.add("collectionFormat %s is dropped: the parameter is serialized with the default style of %s parameters", collectionFormat, in)
+}
+
+func (at reportAt) allowEmptyValue(parameter *openapi2.Parameter) {
+	if parameter.AllowEmptyValue {
+		at.child("allowEmptyValue").add("allowEmptyValue is set on the schema instead of the parameter")
+	}
+}
+
+// parameter reports the fields of a parameter, or of a header given the "header" kind,
+// that Swagger 2.0 lacks.
+func (at reportAt) parameter(parameter *openapi3.Parameter, kind string) {
+	if at.report == nil {
+		return
+	}
+	if parameter.In == openapi3.ParameterInCookie {
+		at.child("in").add("cookie parameters have no Swagger 2.0 equivalent and are kept as is")
+	}
+	if parameter.Style != "" {
+		at.child("style").add("style is dropped")
+	}
+	if parameter.Explode != nil {
+		at.child("explode").add("explode is dropped")
+	}
+	if parameter.AllowReserved {
+		at.child("allowReserved").add("allowReserved is dropped")
+	}
+	if parameter.Deprecated {
+		at.child("deprecated").add("deprecated is dropped")
+	}
+	if parameter.Example != nil || len(parameter.Examples) != 0 {
+		at.add("examples of a %s are dropped", kind)
+	}
+	if len(parameter.Content) != 0 {
+		at.child("content").add("content of a %s is dropped", kind)
+	}
+}
+
+func (at reportAt) mediaType(mediaType *openapi3.MediaType) {
+	if at.report == nil || mediaType == nil {
+		return
+	}
+	if mediaType.Example != nil || len(mediaType.Examples) != 0 {
+		at.add("examples of a media type are dropped")
+	}
+	if len(mediaType.Encoding) != 0 {
+		at.child("encoding").add("encoding is dropped")
+	}
+}
+
+// schema reports the fields of a schema that Swagger 2.0 lacks.
+func (at reportAt) schema(schema *openapi3.Schema) {
+	if at.report == nil {
+		return
+	}
+	if len(schema.OneOf) != 0 {
+		at.child("oneOf").add("oneOf has no Swagger 2.0 equivalent")
+	}
+	if len(schema.AnyOf) != 0 {
+		at.child("anyOf").add("anyOf has no Swagger 2.0 equivalent")
+	}
+	if schema.Not != nil {
+		at.child("not").add("not has no Swagger 2.0 equivalent")
+	}
+	if schema.Nullable {
+		at.child("nullable").add("nullable has no Swagger 2.0 equivalent")
+	}
+	if schema.WriteOnly {
+		at.child("writeOnly").add("writeOnly has no Swagger 2.0 equivalent")
+	}
+	if schema.Discriminator != nil {
+		at.child("discriminator").add("discriminator objects have no Swagger 2.0 equivalent, where a discriminator is a property name")
+	}
+}
+
+// schemaRef reports an inline schema the conversion keeps as is, and its subschemas.
+func (at reportAt) schemaRef(ref *openapi3.SchemaRef) {
+	if at.report == nil || ref == nil || ref.Ref != "" || ref.Value == nil {
+		return
+	}
+	schema := ref.Value
+	at.schema(schema)
+	for i, v := range schema.OneOf {
+		at.child("oneOf", strconv.Itoa(i)).schemaRef(v)
+	}
+	for i, v := range schema.AnyOf {
+		at.child("anyOf", strconv.Itoa(i)).schemaRef(v)
+	}
+	for i, v := range schema.AllOf {
+		at.child("allOf", strconv.Itoa(i)).schemaRef(v)
+	}
+	at.child("not").schemaRef(schema.Not)
+	at.child("items").schemaRef(schema.Items)
+	for name, v := range schema.Properties {
+		at.child("properties", name).schemaRef(v)
+	}
+	at.child("additionalProperties").schemaRef(schema.AdditionalProperties)
+}
+
+// oauthFlows reports the flows of an oauth2 security scheme past the one
+// FromV3SecurityScheme converts, in the order it picks it.
+func (at reportAt) oauthFlows(flows *openapi3.OAuthFlows) {
+	var converted string
+	for _, flow := range []struct {
+		name  string
+		value *openapi3.OAuthFlow
+	}{
+		{"implicit", flows.Implicit},
+		{"authorizationCode", flows.AuthorizationCode},
+		{"password", flows.Password},
+		{"clientCredentials", flows.ClientCredentials},
+	} {
+		if flow.value == nil {
+			continue
+		}
+		if converted == "" {
+			converted = flow.name
+			continue
+		}
+		at.child("flows", flow.name).add("only the %s flow of an oauth2 security scheme is converted", converted)
+	}
+}
@@ -0,0 +1,162 @@
+package openapi2conv
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi2"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func reportedIssues(report *Report) []string {
+	issues := make([]string, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, issue.String())
+	}
+	return issues
+}
+
+func TestToV3WithReport(t *testing.T) {
+	spec := `{
+  "swagger": "2.0",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "produces": ["application/json", "application/xml"],
+  "paths": {
+    "/pets/{ids}": {
+      "get": {
+        "produces": ["text/csv"],
+        "parameters": [
+          {"in": "path", "name": "ids", "required": true, "type": "array", "items": {"type": "string"}},
+          {"in": "query", "name": "tags", "type": "array", "items": {"type": "string"}, "collectionFormat": "tsv"},
+          {"in": "query", "name": "kinds", "type": "array", "items": {"type": "string"}, "collectionFormat": "multi"},
+          {"in": "query", "name": "names", "type": "array", "items": {"type": "string"}},
+          {"in": "query", "name": "q", "type": "string", "allowEmptyValue": true}
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "headers": {"X-Ids": {"type": "array", "items": {"type": "string"}, "collectionFormat": "pipes"}},
+            "examples": {"application/json": []}
+          }
+        }
+      }
+    }
+  }
+}`
+	var doc2 openapi2.T
+	err := json.Unmarshal([]byte(spec), &doc2)
+	require.NoError(t, err)
+
+	doc3, report, err := ToV3WithReport(&doc2)
+	require.NoError(t, err)
+	require.NotNil(t, doc3.Paths["/pets/{ids}"].Get)
+	require.Equal(t, []string{
+		"/paths/~1pets~1{ids}/get/parameters/1/collectionFormat: collectionFormat tsv has no OpenAPI 3 equivalent",
+		"/paths/~1pets~1{ids}/get/parameters/3: the default collectionFormat csv is dropped: the parameter is serialized with the default style of query parameters",
+		"/paths/~1pets~1{ids}/get/parameters/4/allowEmptyValue: allowEmptyValue is set on the schema instead of the parameter",
+		"/paths/~1pets~1{ids}/get/produces: produces is dropped: response schemas are converted to application/json content",
+		"/paths/~1pets~1{ids}/get/responses/200/examples: examples of a response are dropped",
+		"/paths/~1pets~1{ids}/get/responses/200/headers/X-Ids/collectionFormat: collectionFormat pipes is dropped: the parameter is serialized with the default style of header parameters",
+		"/produces: produces is dropped: response schemas are converted to application/json content",
+	}, reportedIssues(report))
+}
+
+func TestFromV3WithReport(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "servers": [
+    {"url": "https://pets.example.com/v1"},
+    {"url": "http://pets.example.com/v1"},
+    {"url": "https://staging.example.com/v1"}
+  ],
+  "paths": {
+    "/pets": {
+      "post": {
+        "parameters": [
+          {"in": "cookie", "name": "session", "schema": {"type": "string"}},
+          {"in": "query", "name": "tags", "style": "pipeDelimited", "schema": {"type": "array", "items": {"type": "string"}}}
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {"schema": {"$ref": "#/components/schemas/Pet"}},
+            "application/xml": {"schema": {"$ref": "#/components/schemas/Pet"}}
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "Created",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/Pet"}},
+              "text/plain": {"schema": {"type": "string"}}
+            },
+            "links": {"self": {"operationId": "getPet"}}
+          }
+        },
+        "callbacks": {
+          "created": {
+            "{$request.body#/callback}": {
+              "post": {"responses": {"200": {"description": "OK"}}}
+            }
+          }
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string", "nullable": true},
+          "owner": {"oneOf": [{"type": "string"}, {"type": "integer", "not": {"minimum": 0}}]}
+        }
+      }
+    },
+    "securitySchemes": {
+      "bearer": {"type": "http", "scheme": "bearer"},
+      "oauth": {
+        "type": "oauth2",
+        "flows": {
+          "implicit": {"authorizationUrl": "https://example.com/auth", "scopes": {}},
+          "password": {"tokenUrl": "https://example.com/token", "scopes": {}}
+        }
+      }
+    }
+  }
+}`
+	var doc3 openapi3.T
+	err := json.Unmarshal([]byte(spec), &doc3)
+	require.NoError(t, err)
+	err = openapi3.NewLoader().ResolveRefsIn(&doc3, nil)
+	require.NoError(t, err)
+
+	doc2, report, err := FromV3WithReport(&doc3)
+	require.NoError(t, err)
+	require.Equal(t, "pets.example.com", doc2.Host)
+	require.Equal(t, []string{
+		"/components/schemas/Pet/properties/name/nullable: nullable has no Swagger 2.0 equivalent",
+		"/components/schemas/Pet/properties/owner/oneOf: oneOf has no Swagger 2.0 equivalent",
+		"/components/schemas/Pet/properties/owner/oneOf/1/not: not has no Swagger 2.0 equivalent",
+		"/components/securitySchemes/bearer/scheme: http bearer authentication is rewritten as an apiKey in the Authorization header",
+		"/components/securitySchemes/oauth/flows/password: only the implicit flow of an oauth2 security scheme is converted",
+		"/paths/~1pets/post/callbacks: callbacks have no Swagger 2.0 equivalent and are dropped",
+		"/paths/~1pets/post/parameters/0/in: cookie parameters have no Swagger 2.0 equivalent and are kept as is",
+		"/paths/~1pets/post/parameters/1/style: style is dropped",
+		"/paths/~1pets/post/requestBody/content: only one of the 2 media types of the request body is converted",
+		"/paths/~1pets/post/responses/201/content/text~1plain: only application/json content of a response is converted",
+		"/paths/~1pets/post/responses/201/links: links have no Swagger 2.0 equivalent and are dropped",
+		"/servers/2: only the first server provides the host and basePath",
+	}, reportedIssues(report))
+}
+
+func TestToV3WithReportLossless(t *testing.T) {
+	var doc2 openapi2.T
+	err := json.Unmarshal([]byte(`{"swagger": "2.0", "info": {"title": "Pets", "version": "1.0.0"}, "paths": {}}`), &doc2)
+	require.NoError(t, err)
+	_, report, err := ToV3WithReport(&doc2)
+	require.NoError(t, err)
+	require.Empty(t, report.Issues)
+}
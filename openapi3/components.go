@@ -40,6 +40,7 @@ func (components *Components) UnmarshalJSON(data []byte) error {
 
 // Validate returns an error if Components does not comply with the OpenAPI spec.
 func (components *Components) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(components.Origin, err) }()
 	for k, v := range components.Schemas {
 		if err = ValidateIdentifier(k); err != nil {
 			return
@@ -2,6 +2,7 @@ package openapi3
 
 import (
 	"context"
+	"mime"
 	"strings"
 )
 
@@ -61,6 +62,12 @@ func NewContentWithFormDataSchemaRef(schema *SchemaRef) Content {
 	}
 }
 
+// Get returns the media type of the content that matches a media type, such as the Content-Type of a body.
+// The media type is matched by the content's media types without wildcards first, then by those of the
+// form x/*, then by */*. Among them, those whose parameters are all parameters of the media type match,
+// the one with the most parameters first, so that "application/json; version=2" is matched by
+// "application/json; version=2" rather than by "application/json". Media types and charsets are
+// case-insensitive. An empty media type is matched by */* only.
 func (content Content) Get(mime string) *MediaType {
 	// If the mime is empty then short-circuit to the wildcard.
 	// We do this here so that we catch only the specific case of
@@ -73,35 +80,85 @@ func (content Content) Get(mime string) *MediaType {
 	if v := content[mime]; v != nil {
 		return v
 	}
-	// If an exact match is not found then we strip all
-	// metadata from the mime type and only use the x/y
-	// portion.
-	i := strings.IndexByte(mime, ';')
-	if i < 0 {
-		// If there is no metadata then preserve the full mime type
-		// string for later wildcard searches.
-		i = len(mime)
-	}
-	mime = mime[:i]
-	if v := content[mime]; v != nil {
-		return v
-	}
-	// If the x/y pattern has no specific match then we
-	// try the x/* pattern.
-	i = strings.IndexByte(mime, '/')
-	if i < 0 {
+	mediaType, ok := ParseMediaRange(mime)
+	if !ok {
 		// In the case that the given mime type is not valid because it is
 		// missing the subtype we return nil so that this does not accidentally
 		// resolve with the wildcard.
 		return nil
 	}
-	mime = mime[:i] + "/*"
-	if v := content[mime]; v != nil {
-		return v
+	var (
+		match            *MediaType
+		matchKey         string
+		matchSpecificity = -1
+	)
+	for key, v := range content {
+		if v == nil {
+			continue
+		}
+		mediaRange, ok := ParseMediaRange(key)
+		if !ok || !mediaRange.Includes(mediaType) {
+			continue
+		}
+		// Among equally specific keys, the first in order wins so that the result is stable
+		if n := mediaRange.Specificity(); n > matchSpecificity || (n == matchSpecificity && key < matchKey) {
+			match, matchKey, matchSpecificity = v, key, n
+		}
+	}
+	return match
+}
+
+// MediaRange is a media type, such as "application/json; charset=utf-8", or a range of media types,
+// such as "text/*" or "*/*", as the keys of Content, the contentType of encodings and Accept headers have.
+type MediaRange struct {
+	// Type and Subtype are lowercase. They are "*" in ranges.
+	Type, Subtype string
+	Params        map[string]string
+}
+
+// ParseMediaRange parses a media type or range. Parameters which do not parse are ignored.
+func ParseMediaRange(value string) (MediaRange, bool) {
+	mediaType, params, err := mime.ParseMediaType(value)
+	if err != nil {
+		if i := strings.IndexByte(value, ';'); i >= 0 {
+			value = value[:i]
+		}
+		mediaType, params = strings.ToLower(strings.TrimSpace(value)), nil
+	}
+	i := strings.IndexByte(mediaType, '/')
+	if i <= 0 || i == len(mediaType)-1 {
+		return MediaRange{}, false
+	}
+	return MediaRange{Type: mediaType[:i], Subtype: mediaType[i+1:], Params: params}, true
+}
+
+// Includes tells whether a media type is in the range: their types and subtypes are equal,
+// or those of the range are wildcards, and the media type has all the parameters of the range,
+// charsets being case-insensitive. A range includes the ranges it is wider than.
+func (r MediaRange) Includes(mediaType MediaRange) bool {
+	if r.Type != "*" && r.Type != mediaType.Type || r.Subtype != "*" && r.Subtype != mediaType.Subtype {
+		return false
+	}
+	for name, value := range r.Params {
+		v, ok := mediaType.Params[name]
+		if !ok || v != value && !(name == "charset" && strings.EqualFold(v, value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Specificity ranks the ranges that include a media type: type/subtype is more specific than
+// type/*, which is more specific than */*, then the range with the most parameters is.
+func (r MediaRange) Specificity() int {
+	n := len(r.Params)
+	if r.Type != "*" {
+		n += 1000
+	}
+	if r.Subtype != "*" {
+		n += 1000
 	}
-	// Finally, the most generic match of */* is returned
-	// as a catch-all.
-	return content["*/*"]
+	return n
 }
 
 // Validate returns an error if Content does not comply with the OpenAPI spec.
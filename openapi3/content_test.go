@@ -111,3 +111,65 @@ func TestContent_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestContent_GetParameters(t *testing.T) {
+	v1 := NewMediaType()
+	v2 := NewMediaType()
+	unversioned := NewMediaType()
+	utf8 := NewMediaType()
+	text := NewMediaType()
+	content := Content{
+		"application/json; version=1": v1,
+		"application/json; version=2": v2,
+		"application/json":            unversioned,
+		"text/plain; charset=utf-8":   utf8,
+		"text/*":                      text,
+	}
+	tests := []struct {
+		mime string
+		want *MediaType
+	}{
+		{mime: "application/json; version=2", want: v2},
+		{mime: "application/json; charset=utf-8; version=1", want: v1},
+		{mime: "Application/JSON; version=3", want: unversioned},
+		{mime: "application/json", want: unversioned},
+		{mime: "text/plain; charset=UTF-8", want: utf8},
+		{mime: "text/plain; charset=iso-8859-1", want: text},
+		{mime: "text/plain", want: text},
+		{mime: "application/xml", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mime, func(t *testing.T) {
+			require.Same(t, tt.want, content.Get(tt.mime))
+		})
+	}
+}
+
+func TestMediaRangeIncludes(t *testing.T) {
+	tests := []struct {
+		mediaRange string
+		mediaType  string
+		want       bool
+	}{
+		{mediaRange: "*/*", mediaType: "image/png", want: true},
+		{mediaRange: "image/*", mediaType: "IMAGE/PNG", want: true},
+		{mediaRange: "image/*", mediaType: "text/plain", want: false},
+		{mediaRange: "image/png", mediaType: "image/*", want: false},
+		{mediaRange: "text/*", mediaType: "text/*", want: true},
+		{mediaRange: "text/plain; charset=UTF-8", mediaType: "text/plain; charset=utf-8; format=flowed", want: true},
+		{mediaRange: "text/plain; format=fixed", mediaType: "text/plain; format=flowed", want: false},
+		{mediaRange: "text/plain; charset=utf-8", mediaType: "text/plain", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mediaRange+" "+tt.mediaType, func(t *testing.T) {
+			mediaRange, ok := ParseMediaRange(tt.mediaRange)
+			require.True(t, ok)
+			mediaType, ok := ParseMediaRange(tt.mediaType)
+			require.True(t, ok)
+			require.Equal(t, tt.want, mediaRange.Includes(mediaType))
+		})
+	}
+
+	_, ok := ParseMediaRange("text")
+	require.False(t, ok)
+}
@@ -26,6 +26,7 @@ func (discriminator *Discriminator) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Discriminator does not comply with the OpenAPI spec.
-func (discriminator *Discriminator) Validate(ctx context.Context) error {
+func (discriminator *Discriminator) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(discriminator.Origin, err) }()
 	return nil
 }
@@ -65,10 +65,11 @@ func (encoding *Encoding) SerializationMethod() *SerializationMethod {
 }
 
 // Validate returns an error if Encoding does not comply with the OpenAPI spec.
-func (encoding *Encoding) Validate(ctx context.Context) error {
+func (encoding *Encoding) Validate(ctx context.Context) (err error) {
 	if encoding == nil {
 		return nil
 	}
+	defer func() { err = withOrigin(encoding.Origin, err) }()
 	for k, v := range encoding.Headers {
 		if err := ValidateIdentifier(k); err != nil {
 			return nil
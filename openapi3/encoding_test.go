@@ -11,7 +11,7 @@ import (
 
 func TestEncodingJSON(t *testing.T) {
 	t.Log("Marshal *openapi3.Encoding to JSON")
-	data, err := json.Marshal(encoding())
+	data, err := json.Marshal(testEncoding())
 	require.NoError(t, err)
 	require.NotEmpty(t, data)
 
@@ -44,7 +44,7 @@ var encodingJSON = []byte(`
 }
 `)
 
-func encoding() *Encoding {
+func testEncoding() *Encoding {
 	explode := true
 	return &Encoding{
 		ContentType: "application/json",
@@ -53,6 +53,7 @@ func (example *Example) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Example does not comply with the OpenAPI spec.
-func (example *Example) Validate(ctx context.Context) error {
+func (example *Example) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(example.Origin, err) }()
 	return nil // TODO
 }
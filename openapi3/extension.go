@@ -8,6 +8,9 @@ import (
 // It reads/writes all properties that begin with "x-".
 type ExtensionProps struct {
 	Extensions map[string]interface{} `json:"-" yaml:"-"`
+
+	// Origin is where the object was loaded from, if the Loader recorded it.
+	Origin *Origin `json:"-" yaml:"-"`
 }
 
 // Assert that the type implements the interface
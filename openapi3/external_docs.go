@@ -29,7 +29,8 @@ func (e *ExternalDocs) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if ExternalDocs does not comply with the OpenAPI spec.
-func (e *ExternalDocs) Validate(ctx context.Context) error {
+func (e *ExternalDocs) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(e.Origin, err) }()
 	if e.URL == "" {
 		return errors.New("url is required")
 	}
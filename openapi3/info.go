@@ -31,7 +31,8 @@ func (info *Info) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Info does not comply with the OpenAPI spec.
-func (info *Info) Validate(ctx context.Context) error {
+func (info *Info) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(info.Origin, err) }()
 	if contact := info.Contact; contact != nil {
 		if err := contact.Validate(ctx); err != nil {
 			return err
@@ -76,7 +77,8 @@ func (contact *Contact) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Contact does not comply with the OpenAPI spec.
-func (contact *Contact) Validate(ctx context.Context) error {
+func (contact *Contact) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(contact.Origin, err) }()
 	return nil
 }
 
@@ -100,7 +102,8 @@ func (license *License) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if License does not comply with the OpenAPI spec.
-func (license *License) Validate(ctx context.Context) error {
+func (license *License) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(license.Origin, err) }()
 	if license.Name == "" {
 		return errors.New("value of license name must be a non-empty string")
 	}
@@ -0,0 +1,166 @@
+package openapi3
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"gopkg.in/yaml.v2"
+)
+
+// recordKeyOrder records the order of the keys of the objects of a document loaded from data.
+func (loader *Loader) recordKeyOrder(doc *T, data []byte, location *url.URL) error {
+	if !loader.PreserveKeyOrder {
+		return nil
+	}
+	l, err := loader.documentLayout(data, location)
+	if err != nil {
+		return err
+	}
+	doc.keyOrder = l.keys
+	return nil
+}
+
+// orderJSON re-encodes a JSON value with the keys of its objects in the given order,
+// by the JSON pointer to the objects. Keys without a recorded order follow, sorted.
+func orderJSON(data []byte, keys map[string][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeOrderedJSON(&buf, data, "", keys); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeOrderedJSON(buf *bytes.Buffer, data json.RawMessage, pointer string, keys map[string][]string) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+	switch data[0] {
+	case '{':
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(data, &members); err != nil {
+			return err
+		}
+		buf.WriteByte('{')
+		for i, key := range orderedKeys(members, keys[pointer]) {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			name, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			buf.Write(name)
+			buf.WriteByte(':')
+			if err := writeOrderedJSON(buf, members[key], pointer+"/"+jsonpointer.Escape(key), keys); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		buf.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeOrderedJSON(buf, item, pointer+"/"+strconv.Itoa(i), keys); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	default:
+		buf.Write(data)
+	}
+	return nil
+}
+
+// orderedKeys returns the keys of an object: those in order first, then the others sorted.
+func orderedKeys(members map[string]json.RawMessage, order []string) []string {
+	result := make([]string, 0, len(members))
+	seen := make(map[string]struct{}, len(order))
+	for _, key := range order {
+		if _, ok := members[key]; ok {
+			if _, ok := seen[key]; !ok {
+				seen[key] = struct{}{}
+				result = append(result, key)
+			}
+		}
+	}
+	others := make([]string, 0, len(members)-len(result))
+	for key := range members {
+		if _, ok := seen[key]; !ok {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+	return append(result, others...)
+}
+
+// yamlValueOf converts a JSON value to a value gopkg.in/yaml.v2 encodes
+// with the same key order, using yaml.MapSlice for objects.
+func yamlValueOf(data []byte) (interface{}, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, nil
+	}
+	switch data[0] {
+	case '{':
+		dec := json.NewDecoder(bytes.NewReader(data))
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		var result yaml.MapSlice
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return nil, err
+			}
+			value, err := yamlValueOf(raw)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, yaml.MapItem{Key: token, Value: value})
+		}
+		return result, nil
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, 0, len(items))
+		for _, item := range items {
+			value, err := yamlValueOf(item)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, value)
+		}
+		return result, nil
+	case '"', 't', 'f', 'n':
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	default:
+		if !strings.ContainsAny(string(data), ".eE") {
+			if i, err := strconv.ParseInt(string(data), 10, 64); err == nil {
+				return i, nil
+			}
+		}
+		return strconv.ParseFloat(string(data), 64)
+	}
+}
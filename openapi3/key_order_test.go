@@ -0,0 +1,144 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+const keyOrderSpec = `openapi: 3.0.0
+info:
+  version: '1.0'
+  title: Pets
+  x-team: pets
+  x-audience: public
+paths:
+  /pets:
+    post:
+      responses:
+        "201":
+          description: Created
+        "400":
+          description: Bad Request
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /owners:
+    get:
+      responses:
+        default:
+          description: Error
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        id:
+          type: integer
+`
+
+func TestPreserveKeyOrder(t *testing.T) {
+	loader := NewLoader()
+	loader.PreserveKeyOrder = true
+	doc, err := loader.LoadFromData([]byte(keyOrderSpec))
+	require.NoError(t, err)
+
+	doc.Paths["/humans"] = &PathItem{Get: &Operation{Responses: Responses{"default": &ResponseRef{Value: NewResponse().WithDescription("Error")}}}}
+	doc.Components.Schemas["Pet"].Value.Properties["age"] = NewIntegerSchema().NewRef()
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.Equal(t, `{"openapi":"3.0.0",`+
+		`"info":{"version":"1.0","title":"Pets","x-team":"pets","x-audience":"public"},`+
+		`"paths":{"/pets":{"post":{"responses":{"201":{"description":"Created"},"400":{"description":"Bad Request"}}},`+
+		`"get":{"responses":{"200":{"description":"OK","content":{"application/json":{"schema":{"$ref":"#/components/schemas/Pet"}}}}}}},`+
+		`"/owners":{"get":{"responses":{"default":{"description":"Error"}}}},`+
+		`"/humans":{"get":{"responses":{"default":{"description":"Error"}}}}},`+
+		`"components":{"schemas":{"Pet":{"type":"object","required":["name"],`+
+		`"properties":{"name":{"type":"string"},"id":{"type":"integer"},"age":{"type":"integer"}}}}}}`, string(data))
+
+	data, err = yaml.Marshal(doc)
+	require.NoError(t, err)
+	require.Equal(t, `openapi: 3.0.0
+info:
+  version: "1.0"
+  title: Pets
+  x-team: pets
+  x-audience: public
+paths:
+  /pets:
+    post:
+      responses:
+        "201":
+          description: Created
+        "400":
+          description: Bad Request
+    get:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /owners:
+    get:
+      responses:
+        default:
+          description: Error
+  /humans:
+    get:
+      responses:
+        default:
+          description: Error
+components:
+  schemas:
+    Pet:
+      type: object
+      required:
+      - name
+      properties:
+        name:
+          type: string
+        id:
+          type: integer
+        age:
+          type: integer
+`, string(data))
+}
+
+func TestWithoutPreserveKeyOrder(t *testing.T) {
+	doc, err := NewLoader().LoadFromData([]byte(keyOrderSpec))
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc.Info)
+	require.NoError(t, err)
+	require.Equal(t, `{"title":"Pets","version":"1.0","x-audience":"public","x-team":"pets"}`, string(data))
+
+	data, err = json.Marshal(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"paths":{"/owners":`)
+}
+
+func TestWithoutPreserveKeyOrderYAML(t *testing.T) {
+	doc, err := NewLoader().LoadFromData([]byte(keyOrderSpec))
+	require.NoError(t, err)
+
+	// Documents are marshaled through their yaml tags, as gopkg.in/yaml.v2 does for structs
+	data, err := yaml.Marshal(doc)
+	require.NoError(t, err)
+	type plainT T
+	expected, err := yaml.Marshal((*plainT)(doc))
+	require.NoError(t, err)
+	require.Equal(t, string(expected), string(data))
+}
@@ -0,0 +1,471 @@
+package openapi3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"gopkg.in/yaml.v2"
+)
+
+// position is a line and a column in a document.
+type position struct {
+	line, column int
+}
+
+// layout holds the positions of the values of a JSON or YAML document
+// and the order of the keys of its objects, by the JSON pointer to them.
+type layout struct {
+	// positions of the values; the position of an object member is that of its key
+	positions map[string]position
+	// keys of the objects, in the order they appear in
+	keys map[string][]string
+}
+
+func newLayout() *layout {
+	return &layout{
+		positions: make(map[string]position),
+		keys:      make(map[string][]string),
+	}
+}
+
+// layoutOf scans the layout of a JSON or YAML document.
+// The block mappings and sequences of a YAML document are scanned line by line, and only
+// the positions and keys of those whose keys and lengths agree with the document the YAML parser
+// decodes are kept. The values of flow collections, aliases and merge keys, and the values
+// of documents the parser fails on, have no position rather than a wrong one.
+func layoutOf(data []byte) *layout {
+	l := newLayout()
+	if trimmed := strings.TrimLeft(string(data), " \t\r\n"); trimmed != "" && (trimmed[0] == '{' || trimmed[0] == '[') {
+		s := &jsonLayoutScanner{data: data, offset: len(data) - len(trimmed), line: 1, column: 1, layout: l}
+		s.scanValue("")
+		return l
+	}
+	scanYAMLLayout(data, l)
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return newLayout()
+	}
+	agreed := newLayout()
+	l.keepAgreed(agreed, doc, "")
+	return agreed
+}
+
+// keepAgreed copies to agreed the positions and keys of value, at pointer, and of
+// the values of the mappings and sequences whose scanned keys and lengths agree with it.
+func (l *layout) keepAgreed(agreed *layout, value interface{}, pointer string) {
+	if p, ok := l.positions[pointer]; ok {
+		agreed.positions[pointer] = p
+	}
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		keys := l.keys[pointer]
+		members := make(map[string]interface{}, len(value))
+		for key, member := range value {
+			members[fmt.Sprint(key)] = member
+		}
+		if len(keys) != len(value) || len(members) != len(value) {
+			return
+		}
+		for _, key := range keys {
+			if _, ok := members[key]; !ok {
+				return
+			}
+		}
+		if len(keys) != 0 {
+			agreed.keys[pointer] = keys
+		}
+		for _, key := range keys {
+			l.keepAgreed(agreed, members[key], pointer+"/"+jsonpointer.Escape(key))
+		}
+	case []interface{}:
+		if _, ok := l.positions[pointer+"/"+strconv.Itoa(len(value))]; ok {
+			return
+		}
+		for i := range value {
+			if _, ok := l.positions[pointer+"/"+strconv.Itoa(i)]; !ok {
+				return
+			}
+		}
+		for i, item := range value {
+			l.keepAgreed(agreed, item, pointer+"/"+strconv.Itoa(i))
+		}
+	}
+}
+
+func (l *layout) addKey(pointer, key string, p position) string {
+	child := pointer + "/" + jsonpointer.Escape(key)
+	if _, ok := l.positions[child]; !ok {
+		l.keys[pointer] = append(l.keys[pointer], key)
+	}
+	l.positions[child] = p
+	return child
+}
+
+type jsonLayoutScanner struct {
+	data         []byte
+	offset       int
+	line, column int
+	// scanned is the offset up to which line and column are computed
+	scanned int
+	layout  *layout
+}
+
+func (s *jsonLayoutScanner) advanceTo(offset int) {
+	for ; s.scanned < offset; s.scanned++ {
+		if s.data[s.scanned] == '\n' {
+			s.line++
+			s.column = 1
+		} else {
+			s.column++
+		}
+	}
+}
+
+func (s *jsonLayoutScanner) here() position {
+	s.advanceTo(s.offset)
+	return position{line: s.line, column: s.column}
+}
+
+func (s *jsonLayoutScanner) skipSpace() {
+	for s.offset < len(s.data) {
+		switch s.data[s.offset] {
+		case ' ', '\t', '\r', '\n':
+			s.offset++
+		default:
+			return
+		}
+	}
+}
+
+func (s *jsonLayoutScanner) scanValue(pointer string) {
+	s.skipSpace()
+	if s.offset >= len(s.data) {
+		return
+	}
+	if _, ok := s.layout.positions[pointer]; !ok {
+		s.layout.positions[pointer] = s.here()
+	}
+	switch s.data[s.offset] {
+	case '{':
+		s.offset++
+		for {
+			s.skipSpace()
+			if s.offset >= len(s.data) {
+				return
+			}
+			switch s.data[s.offset] {
+			case '}':
+				s.offset++
+				return
+			case ',':
+				s.offset++
+				continue
+			case '"':
+			default:
+				return
+			}
+			keyPosition := s.here()
+			key, ok := s.scanString()
+			if !ok {
+				return
+			}
+			s.skipSpace()
+			if s.offset >= len(s.data) || s.data[s.offset] != ':' {
+				return
+			}
+			s.offset++
+			s.scanValue(s.layout.addKey(pointer, key, keyPosition))
+		}
+	case '[':
+		s.offset++
+		for i := 0; ; {
+			s.skipSpace()
+			if s.offset >= len(s.data) {
+				return
+			}
+			switch s.data[s.offset] {
+			case ']':
+				s.offset++
+				return
+			case ',':
+				s.offset++
+				continue
+			}
+			start := s.offset
+			s.scanValue(pointer + "/" + strconv.Itoa(i))
+			if s.offset == start {
+				return
+			}
+			i++
+		}
+	case '"':
+		s.scanString()
+	default:
+		for s.offset < len(s.data) {
+			switch s.data[s.offset] {
+			case ',', '}', ']', ' ', '\t', '\r', '\n':
+				return
+			}
+			s.offset++
+		}
+	}
+}
+
+func (s *jsonLayoutScanner) scanString() (string, bool) {
+	start := s.offset
+	for s.offset++; s.offset < len(s.data); s.offset++ {
+		switch s.data[s.offset] {
+		case '\\':
+			s.offset++
+		case '"':
+			s.offset++
+			value, err := strconv.Unquote(string(s.data[start:s.offset]))
+			if err != nil {
+				return "", false
+			}
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// yamlCollection is a block mapping or sequence being scanned.
+type yamlCollection struct {
+	indent   int
+	pointer  string
+	sequence bool
+	length   int
+}
+
+// scanYAMLLayout scans the layout of the block mappings and sequences of the first document of a YAML stream.
+func scanYAMLLayout(data []byte, l *layout) {
+	positions := l.positions
+	lines := strings.Split(string(data), "\n")
+	var stack []*yamlCollection
+	// pending is the value, empty so far, of the last key or sequence entry:
+	// a block collection may follow on the next lines.
+	var pending *yamlCollection
+
+	for n := 0; n < len(lines); n++ {
+		line := strings.TrimRight(lines[n], "\r")
+		if isYAMLDocumentMarker(line) {
+			if stack != nil {
+				// The end of the first document
+				return
+			}
+			continue
+		}
+		content := strings.TrimLeft(line, " ")
+		if content == "" || content[0] == '#' || content[0] == '%' {
+			continue
+		}
+		indent := len(line) - len(content)
+		isEntry := isYAMLSequenceEntry(content)
+		if stack == nil {
+			positions[""] = position{line: n + 1, column: indent + 1}
+			stack = []*yamlCollection{{indent: indent, sequence: isEntry}}
+		}
+
+		// Enter the collection this line is an entry of. A sequence may have
+		// the indentation of the mapping it is a value of, and ends at its next key.
+		for len(stack) > 1 {
+			top := stack[len(stack)-1]
+			if top.indent < indent || (top.indent == indent && (!top.sequence || isEntry)) {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		var top *yamlCollection
+		if len(stack) > 0 {
+			top = stack[len(stack)-1]
+		}
+		switch {
+		case pending != nil && (indent > pending.indent || (indent == pending.indent && isEntry && !top.sequence)):
+			top = &yamlCollection{indent: indent, pointer: pending.pointer, sequence: isEntry}
+			stack = append(stack, top)
+		case top == nil || top.indent != indent || top.sequence != isEntry:
+			// A continuation of a multi-line scalar
+			pending = nil
+			continue
+		}
+		pending = nil
+
+		// An entry may start with nested entries, as in "- - a" or "- key: value"
+		column := indent
+		for {
+			var rest, pointer string
+			// blockIndent is the indentation block scalar lines must exceed
+			blockIndent := column
+			if top.sequence {
+				pointer = top.pointer + "/" + strconv.Itoa(top.length)
+				top.length++
+				positions[pointer] = position{line: n + 1, column: column + 1}
+				rest = strings.TrimLeft(content[1:], " ")
+				blockIndent = top.indent
+				column += len(content) - len(rest)
+			} else {
+				key, value, ok := splitYAMLKey(content)
+				if !ok {
+					break
+				}
+				pointer = l.addKey(top.pointer, key, position{line: n + 1, column: column + 1})
+				rest = value
+			}
+
+			rest = trimYAMLComment(rest)
+			if rest != "" && rest[0] != '|' && rest[0] != '>' && rest[0] != '{' && rest[0] != '[' && top.sequence {
+				if isYAMLSequenceEntry(rest) {
+					top = &yamlCollection{indent: column, pointer: pointer, sequence: true}
+					stack = append(stack, top)
+					content = rest
+					continue
+				}
+				if _, _, ok := splitYAMLKey(rest); ok {
+					top = &yamlCollection{indent: column, pointer: pointer}
+					stack = append(stack, top)
+					content = rest
+					continue
+				}
+			}
+
+			switch {
+			case rest == "" || rest[0] == '&' && !strings.Contains(rest, " "):
+				pending = &yamlCollection{indent: column, pointer: pointer}
+			case rest[0] == '|' || rest[0] == '>':
+				// Skip the lines of a block scalar
+				for n+1 < len(lines) {
+					next := strings.TrimRight(lines[n+1], "\r")
+					if strings.TrimSpace(next) != "" && len(next)-len(strings.TrimLeft(next, " ")) <= blockIndent {
+						break
+					}
+					n++
+				}
+			case rest[0] == '{' || rest[0] == '[':
+				// Skip the lines of a flow collection
+				depth := yamlFlowDepth(rest)
+				for depth > 0 && n+1 < len(lines) {
+					n++
+					depth += yamlFlowDepth(lines[n])
+				}
+			}
+			break
+		}
+	}
+}
+
+// isYAMLDocumentMarker tells whether a line starts or ends a document of a YAML stream.
+func isYAMLDocumentMarker(line string) bool {
+	for _, marker := range []string{"---", "..."} {
+		if line == marker || strings.HasPrefix(line, marker+" ") || strings.HasPrefix(line, marker+"\t") {
+			return true
+		}
+	}
+	return false
+}
+
+func isYAMLSequenceEntry(content string) bool {
+	return content == "-" || strings.HasPrefix(content, "- ")
+}
+
+// splitYAMLKey splits a "key: value" mapping entry.
+func splitYAMLKey(content string) (key, value string, ok bool) {
+	var end int
+	switch content[0] {
+	case '"':
+		for end = 1; end < len(content) && content[end] != '"'; end++ {
+			if content[end] == '\\' {
+				end++
+			}
+		}
+		if end >= len(content) {
+			return "", "", false
+		}
+		unquoted, err := strconv.Unquote(content[:end+1])
+		if err != nil {
+			return "", "", false
+		}
+		key, end = unquoted, end+1
+	case '\'':
+		for end = 1; end < len(content); end++ {
+			if content[end] == '\'' {
+				if end+1 < len(content) && content[end+1] == '\'' {
+					end++
+					continue
+				}
+				break
+			}
+		}
+		if end >= len(content) {
+			return "", "", false
+		}
+		key, end = strings.Replace(content[1:end], "''", "'", -1), end+1
+	case '#', '?', '{', '[', '-', '|', '>', '&', '*', '!':
+		return "", "", false
+	default:
+		for end = 0; end < len(content); end++ {
+			if content[end] == ':' && (end+1 == len(content) || content[end+1] == ' ' || content[end+1] == '\t') {
+				break
+			}
+		}
+		if end == len(content) {
+			return "", "", false
+		}
+		key = strings.TrimRight(content[:end], " \t")
+	}
+	rest := strings.TrimLeft(content[end:], " \t")
+	if rest == "" || rest[0] != ':' {
+		return "", "", false
+	}
+	if len(rest) > 1 && rest[1] != ' ' && rest[1] != '\t' {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(rest[1:]), true
+}
+
+// trimYAMLComment removes a trailing comment from a value.
+func trimYAMLComment(value string) string {
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+	var quote byte
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			if i == 0 {
+				quote = c
+			}
+		case c == '#' && (value[i-1] == ' ' || value[i-1] == '\t'):
+			return strings.TrimRight(value[:i], " \t")
+		}
+	}
+	return value
+}
+
+// yamlFlowDepth returns how many flow collections a line opens (or closes, if negative).
+func yamlFlowDepth(line string) int {
+	var depth int
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '{' || c == '[':
+			depth++
+		case c == '}' || c == ']':
+			depth--
+		case c == '#' && (i == 0 || line[i-1] == ' '):
+			return depth
+		}
+	}
+	return depth
+}
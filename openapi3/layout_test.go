@@ -0,0 +1,129 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayoutOfYAML(t *testing.T) {
+	data := []byte(`# A comment
+openapi: 3.0.0
+info:
+  title: "Pets"
+  description: |
+    key: not a key
+  version: '1.0'
+paths:
+  /pets:
+    get:
+      tags: [a,
+        b]
+      parameters:
+      - name: limit
+        in: query
+      -   $ref: '#/components/parameters/Tag' # a comment
+      responses:
+        "200":
+          description: OK
+components:
+  parameters:
+    Tag:
+      name: tag
+`)
+	positions := layoutOf(data).positions
+	for pointer, expected := range map[string]position{
+		"":                                    {2, 1},
+		"/openapi":                            {2, 1},
+		"/info/title":                         {4, 3},
+		"/info/description":                   {5, 3},
+		"/info/version":                       {7, 3},
+		"/paths/~1pets/get":                   {10, 5},
+		"/paths/~1pets/get/tags":              {11, 7},
+		"/paths/~1pets/get/parameters":        {13, 7},
+		"/paths/~1pets/get/parameters/0":      {14, 7},
+		"/paths/~1pets/get/parameters/0/in":   {15, 9},
+		"/paths/~1pets/get/parameters/1":      {16, 7},
+		"/paths/~1pets/get/parameters/1/$ref": {16, 11},
+		"/paths/~1pets/get/responses/200":     {18, 9},
+		"/components/parameters/Tag/name":     {23, 7},
+	} {
+		require.Equal(t, expected, positions[pointer], pointer)
+	}
+	require.NotContains(t, positions, "/info/description/key")
+	require.NotContains(t, positions, "/paths/~1pets/get/tags/0")
+
+	keys := layoutOf(data).keys
+	require.Equal(t, []string{"openapi", "info", "paths", "components"}, keys[""])
+	require.Equal(t, []string{"title", "description", "version"}, keys["/info"])
+	require.Equal(t, []string{"tags", "parameters", "responses"}, keys["/paths/~1pets/get"])
+	require.Equal(t, []string{"name", "in"}, keys["/paths/~1pets/get/parameters/0"])
+	require.NotContains(t, keys, "/paths/~1pets/get/parameters")
+}
+
+func TestLayoutOfJSON(t *testing.T) {
+	data := []byte(`{
+  "openapi": "3.0.0",
+  "paths": {"/pets": {
+    "get": {"parameters": [{"name": "a\"b"}, {"name": "c"}]}
+  }}
+}`)
+	positions := layoutOf(data).positions
+	for pointer, expected := range map[string]position{
+		"":                                    {1, 1},
+		"/openapi":                            {2, 3},
+		"/paths/~1pets":                       {3, 13},
+		"/paths/~1pets/get/parameters/0":      {4, 28},
+		"/paths/~1pets/get/parameters/1":      {4, 46},
+		"/paths/~1pets/get/parameters/1/name": {4, 47},
+	} {
+		require.Equal(t, expected, positions[pointer], pointer)
+	}
+}
+
+func TestLayoutOfYAMLSequenceAtKeyIndentation(t *testing.T) {
+	data := []byte(`tags:
+- name: b
+- name: a
+paths: {}
+servers:
+  - url: /
+`)
+	l := layoutOf(data)
+	require.Equal(t, []string{"tags", "paths", "servers"}, l.keys[""])
+	require.Equal(t, position{3, 1}, l.positions["/tags/1"])
+	require.Equal(t, position{6, 3}, l.positions["/servers/0"])
+	require.Equal(t, position{6, 5}, l.positions["/servers/0/url"])
+}
+
+func TestLayoutOfYAMLWithoutScannablePositions(t *testing.T) {
+	data := []byte(`components:
+  schemas:
+    Base: &base
+      type: object
+      description: base
+    Pet:
+      <<: *base
+      title: Pet
+    Alias: *base
+    Tag: {type: string}
+---
+components: {}
+`)
+	l := layoutOf(data)
+	for pointer, expected := range map[string]position{
+		"/components/schemas/Base/type": {4, 7},
+		"/components/schemas/Pet":       {6, 5},
+		"/components/schemas/Alias":     {9, 5},
+		"/components/schemas/Tag":       {10, 5},
+	} {
+		require.Equal(t, expected, l.positions[pointer], pointer)
+	}
+	require.NotContains(t, l.positions, "/components/schemas/Pet/title")
+	require.NotContains(t, l.positions, "/components/schemas/Alias/type")
+	require.NotContains(t, l.positions, "/components/schemas/Tag/type")
+	require.NotContains(t, l.keys, "/components/schemas/Pet")
+	require.Equal(t, []string{"Base", "Pet", "Alias", "Tag"}, l.keys["/components/schemas"])
+
+	require.Empty(t, layoutOf([]byte("a: [1\n")).positions)
+}
@@ -50,7 +50,8 @@ func (link *Link) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Link does not comply with the OpenAPI spec.
-func (link *Link) Validate(ctx context.Context) error {
+func (link *Link) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(link.Origin, err) }()
 	if link.OperationID == "" && link.OperationRef == "" {
 		return errors.New("missing operationId or operationRef on link")
 	}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/ghodss/yaml"
+	"github.com/go-openapi/jsonpointer"
 )
 
 func foundUnresolvedRef(ref string) error {
@@ -33,8 +34,24 @@ type Loader struct {
 
 	Context context.Context
 
+	// IncludeOrigin records the document, line and column each object is loaded from
+	// in its ExtensionProps.Origin, and includes them in errors about the object.
+	// Objects of YAML documents that are in flow collections, such as {type: string},
+	// or that are reached through aliases or merge keys have no origin.
+	IncludeOrigin bool
+
+	// PreserveKeyOrder records the order of the keys of the objects of the loaded documents,
+	// so that they are marshaled in that order rather than sorted. New keys follow, sorted.
+	PreserveKeyOrder bool
+
 	rootDir string
 
+	// refOrigins holds the origins of the references recorded with IncludeOrigin
+	refOrigins map[interface{}]*Origin
+	// documentLayouts holds the layouts of the documents read with IncludeOrigin
+	// or PreserveKeyOrder, by location
+	documentLayouts map[string]*layout
+
 	visitedPathItemRefs map[string]struct{}
 
 	visitedDocuments map[string]*T
@@ -111,6 +128,9 @@ func (loader *Loader) loadSingleElementFromURI(ref string, rootPath *url.URL, el
 	if err := yaml.Unmarshal(data, element); err != nil {
 		return nil, err
 	}
+	if err := loader.recordOrigins(element, data, resolvedPath, ""); err != nil {
+		return nil, err
+	}
 
 	return resolvedPath, nil
 }
@@ -129,6 +149,12 @@ func (loader *Loader) LoadFromData(data []byte) (*T, error) {
 	if err := yaml.Unmarshal(data, doc); err != nil {
 		return nil, err
 	}
+	if err := loader.recordOrigins(doc, data, nil, ""); err != nil {
+		return nil, err
+	}
+	if err := loader.recordKeyOrder(doc, data, nil); err != nil {
+		return nil, err
+	}
 	if err := loader.ResolveRefsIn(doc, nil); err != nil {
 		return nil, err
 	}
@@ -157,6 +183,12 @@ func (loader *Loader) loadFromDataWithPathInternal(data []byte, location *url.UR
 	if err := yaml.Unmarshal(data, doc); err != nil {
 		return nil, err
 	}
+	if err := loader.recordOrigins(doc, data, location, ""); err != nil {
+		return nil, err
+	}
+	if err := loader.recordKeyOrder(doc, data, location); err != nil {
+		return nil, err
+	}
 	if err := loader.ResolveRefsIn(doc, location); err != nil {
 		return nil, err
 	}
@@ -277,7 +309,7 @@ func (loader *Loader) resolveComponent(
 
 	drill := func(cursor interface{}) (interface{}, error) {
 		for _, pathPart := range strings.Split(fragment[1:], "/") {
-			pathPart = unescapeRefString(pathPart)
+			pathPart = jsonpointer.Unescape(pathPart)
 
 			if cursor, err = drillIntoField(cursor, pathPart); err != nil {
 				e := failedToResolveRefFragmentPart(ref, pathPart)
@@ -290,12 +322,13 @@ func (loader *Loader) resolveComponent(
 		return cursor, nil
 	}
 	var cursor interface{}
+	var data []byte
 	if cursor, err = drill(doc); err != nil {
 		if path == nil {
 			return nil, err
 		}
 		var err2 error
-		data, err2 := loader.readURL(path)
+		data, err2 = loader.readURL(path)
 		if err2 != nil {
 			return nil, err
 		}
@@ -327,6 +360,16 @@ func (loader *Loader) resolveComponent(
 		if err := codec(cursor, resolved); err != nil {
 			return nil, fmt.Errorf("bad data in %q", ref)
 		}
+		if loader.IncludeOrigin {
+			// The data was read from path, or is the part of the referenced document its model does not hold
+			location := path
+			if data == nil && componentPath != nil {
+				location = componentPath
+			}
+			if err := loader.recordOrigins(resolved, data, location, fragment); err != nil {
+				return nil, err
+			}
+		}
 		return componentPath, nil
 
 	default:
@@ -431,7 +474,7 @@ func (loader *Loader) resolveRef(doc *T, ref string, path *url.URL) (*T, string,
 	}
 
 	if doc, err = loader.loadFromURIInternal(resolvedPath); err != nil {
-		return nil, "", nil, fmt.Errorf("error resolving reference %q: %v", ref, err)
+		return nil, "", nil, fmt.Errorf("error resolving reference %q: %w", ref, err)
 	}
 
 	return doc, "#" + fragment, resolvedPath, nil
@@ -455,14 +498,14 @@ func (loader *Loader) resolveHeaderRef(doc *T, component *HeaderRef, documentPat
 		if isSingleRefElement(ref) {
 			var header Header
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &header); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &header
 		} else {
 			var resolved HeaderRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveHeaderRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -503,14 +546,14 @@ func (loader *Loader) resolveParameterRef(doc *T, component *ParameterRef, docum
 		if isSingleRefElement(ref) {
 			var param Parameter
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &param); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &param
 		} else {
 			var resolved ParameterRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveParameterRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -560,14 +603,14 @@ func (loader *Loader) resolveRequestBodyRef(doc *T, component *RequestBodyRef, d
 		if isSingleRefElement(ref) {
 			var requestBody RequestBody
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &requestBody); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &requestBody
 		} else {
 			var resolved RequestBodyRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err = loader.resolveRequestBodyRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -616,14 +659,14 @@ func (loader *Loader) resolveResponseRef(doc *T, component *ResponseRef, documen
 		if isSingleRefElement(ref) {
 			var resp Response
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &resp); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &resp
 		} else {
 			var resolved ResponseRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveResponseRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -686,14 +729,14 @@ func (loader *Loader) resolveSchemaRef(doc *T, component *SchemaRef, documentPat
 		if isSingleRefElement(ref) {
 			var schema Schema
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &schema); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &schema
 		} else {
 			var resolved SchemaRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveSchemaRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -764,14 +807,14 @@ func (loader *Loader) resolveSecuritySchemeRef(doc *T, component *SecurityScheme
 		if isSingleRefElement(ref) {
 			var scheme SecurityScheme
 			if _, err = loader.loadSingleElementFromURI(ref, documentPath, &scheme); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &scheme
 		} else {
 			var resolved SecuritySchemeRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveSecuritySchemeRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -801,14 +844,14 @@ func (loader *Loader) resolveExampleRef(doc *T, component *ExampleRef, documentP
 		if isSingleRefElement(ref) {
 			var example Example
 			if _, err = loader.loadSingleElementFromURI(ref, documentPath, &example); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &example
 		} else {
 			var resolved ExampleRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveExampleRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -829,14 +872,14 @@ func (loader *Loader) resolveCallbackRef(doc *T, component *CallbackRef, documen
 		if isSingleRefElement(ref) {
 			var resolved Callback
 			if documentPath, err = loader.loadSingleElementFromURI(ref, documentPath, &resolved); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &resolved
 		} else {
 			var resolved CallbackRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveCallbackRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -866,6 +909,8 @@ func (loader *Loader) resolveCallbackRef(doc *T, component *CallbackRef, documen
 			if pathItem == nil {
 				return errors.New("invalid path item: value MUST be an object")
 			}
+			origin := pathItem.Origin
+			defer func() { err = withOrigin(origin, err) }()
 			ref := pathItem.Ref
 			if ref != "" {
 				if isSingleRefElement(ref) {
@@ -883,7 +928,7 @@ func (loader *Loader) resolveCallbackRef(doc *T, component *CallbackRef, documen
 					if rest == ref {
 						return fmt.Errorf(`expected prefix "#/components/callbacks/" in URI %q`, ref)
 					}
-					id := unescapeRefString(rest)
+					id := jsonpointer.Unescape(rest)
 
 					definitions := doc.Components.Callbacks
 					if definitions == nil {
@@ -927,14 +972,14 @@ func (loader *Loader) resolveLinkRef(doc *T, component *LinkRef, documentPath *u
 		if isSingleRefElement(ref) {
 			var link Link
 			if _, err = loader.loadSingleElementFromURI(ref, documentPath, &link); err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			component.Value = &link
 		} else {
 			var resolved LinkRef
 			componentPath, err := loader.resolveComponent(doc, ref, documentPath, &resolved)
 			if err != nil {
-				return err
+				return withOrigin(loader.refOrigin(component), err)
 			}
 			if err := loader.resolveLinkRef(doc, &resolved, componentPath); err != nil {
 				return err
@@ -960,6 +1005,8 @@ func (loader *Loader) resolvePathItemRef(doc *T, entrypoint string, pathItem *Pa
 	if pathItem == nil {
 		return errors.New("invalid path item: value MUST be an object")
 	}
+	origin := pathItem.Origin
+	defer func() { err = withOrigin(origin, err) }()
 	ref := pathItem.Ref
 	if ref != "" {
 		if isSingleRefElement(ref) {
@@ -977,7 +1024,7 @@ func (loader *Loader) resolvePathItemRef(doc *T, entrypoint string, pathItem *Pa
 			if rest == ref {
 				return fmt.Errorf(`expected prefix "#/paths/" in URI %q`, ref)
 			}
-			id := unescapeRefString(rest)
+			id := jsonpointer.Unescape(rest)
 
 			definitions := doc.Paths
 			if definitions == nil {
@@ -1024,7 +1071,3 @@ func (loader *Loader) resolvePathItemRefContinued(doc *T, pathItem *PathItem, do
 	}
 	return
 }
-
-func unescapeRefString(ref string) string {
-	return strings.Replace(strings.Replace(ref, "~1", "/", -1), "~0", "~", -1)
-}
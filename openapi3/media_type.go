@@ -71,10 +71,11 @@ func (mediaType *MediaType) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if MediaType does not comply with the OpenAPI spec.
-func (mediaType *MediaType) Validate(ctx context.Context) error {
+func (mediaType *MediaType) Validate(ctx context.Context) (err error) {
 	if mediaType == nil {
 		return nil
 	}
+	defer func() { err = withOrigin(mediaType.Origin, err) }()
 	if schema := mediaType.Schema; schema != nil {
 		if err := schema.Validate(ctx); err != nil {
 			return err
@@ -21,11 +21,36 @@ type T struct {
 	Servers      Servers              `json:"servers,omitempty" yaml:"servers,omitempty"`
 	Tags         Tags                 `json:"tags,omitempty" yaml:"tags,omitempty"`
 	ExternalDocs *ExternalDocs        `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+
+	// keyOrder holds the order of the keys of the objects of the document, by their
+	// JSON pointer, when loaded by a Loader that preserves it.
+	keyOrder map[string][]string
 }
 
 // MarshalJSON returns the JSON encoding of T.
+// The keys of its objects are in the order they were loaded in, if the Loader preserved it.
 func (doc *T) MarshalJSON() ([]byte, error) {
-	return jsoninfo.MarshalStrictStruct(doc)
+	data, err := jsoninfo.MarshalStrictStruct(doc)
+	if err != nil || doc.keyOrder == nil {
+		return data, err
+	}
+	return orderJSON(data, doc.keyOrder)
+}
+
+// yamlT is T without its MarshalYAML method, which gopkg.in/yaml.v2 marshals through its yaml tags.
+type yamlT T
+
+// MarshalYAML returns the YAML encoding of T for gopkg.in/yaml.v2.
+// If the Loader preserved the order of its keys, they are in the same order as with MarshalJSON.
+func (doc *T) MarshalYAML() (interface{}, error) {
+	if doc.keyOrder == nil {
+		return (*yamlT)(doc), nil
+	}
+	data, err := doc.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return yamlValueOf(data)
 }
 
 // UnmarshalJSON sets T to a copy of data.
@@ -0,0 +1,177 @@
+package openapi3
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+
+	"github.com/getkin/kin-openapi/jsoninfo"
+	"github.com/go-openapi/jsonpointer"
+)
+
+// Origin is the location of an object in the document it was loaded from.
+// It is recorded by a Loader whose IncludeOrigin field is set.
+type Origin struct {
+	// Document is the location of the document, as given to the Loader.
+	// It is empty for documents loaded from data without a path.
+	Document string
+	// Line and Column are 1-based; columns are counted in bytes.
+	Line   int
+	Column int
+}
+
+// String returns the origin as document:line:column.
+func (origin *Origin) String() string {
+	position := strconv.Itoa(origin.Line) + ":" + strconv.Itoa(origin.Column)
+	if origin.Document == "" {
+		return position
+	}
+	return origin.Document + ":" + position
+}
+
+// OriginError is an error about an object whose origin is known.
+type OriginError struct {
+	Origin *Origin
+	Err    error
+}
+
+func (err *OriginError) Error() string {
+	return err.Origin.String() + ": " + err.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (err *OriginError) Unwrap() error {
+	return err.Err
+}
+
+// withOrigin annotates an error with the origin of the object it is about,
+// unless it is already annotated with the origin of a nested object.
+func withOrigin(origin *Origin, err error) error {
+	if err == nil || origin == nil {
+		return err
+	}
+	var originErr *OriginError
+	if errors.As(err, &originErr) {
+		return err
+	}
+	return &OriginError{Origin: origin, Err: err}
+}
+
+var extensionPropsType = reflect.TypeOf(ExtensionProps{})
+
+// recordOrigins sets the origins of the objects unmarshaled into value
+// from data, or from the part of data at the given JSON pointer.
+// Without data, the document at location is read unless its layout is known already.
+func (loader *Loader) recordOrigins(value interface{}, data []byte, location *url.URL, pointer string) error {
+	if !loader.IncludeOrigin {
+		return nil
+	}
+	l, err := loader.documentLayout(data, location)
+	if err != nil {
+		return err
+	}
+	var document string
+	if location != nil {
+		document = location.String()
+	}
+	r := &originRecorder{
+		loader:    loader,
+		document:  document,
+		positions: l.positions,
+	}
+	r.record(reflect.ValueOf(value), pointer)
+	return nil
+}
+
+// documentLayout returns the layout of the document at location, scanned from data.
+// Layouts are scanned once per location, and documents without data are read only if theirs is unknown.
+func (loader *Loader) documentLayout(data []byte, location *url.URL) (*layout, error) {
+	if location == nil {
+		return layoutOf(data), nil
+	}
+	uri := location.String()
+	if l, ok := loader.documentLayouts[uri]; ok {
+		return l, nil
+	}
+	if data == nil {
+		var err error
+		if data, err = loader.readURL(location); err != nil {
+			return nil, err
+		}
+	}
+	if loader.documentLayouts == nil {
+		loader.documentLayouts = make(map[string]*layout)
+	}
+	l := layoutOf(data)
+	loader.documentLayouts[uri] = l
+	return l, nil
+}
+
+type originRecorder struct {
+	loader    *Loader
+	document  string
+	positions map[string]position
+}
+
+func (r *originRecorder) origin(pointer string) *Origin {
+	if p, ok := r.positions[pointer]; ok {
+		return &Origin{Document: r.document, Line: p.line, Column: p.column}
+	}
+	return nil
+}
+
+func (r *originRecorder) record(v reflect.Value, pointer string) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			r.record(v.Elem(), pointer)
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return
+		}
+		iter := v.MapRange()
+		for iter.Next() {
+			r.record(iter.Value(), pointer+"/"+jsonpointer.Escape(iter.Key().String()))
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			r.record(v.Index(i), pointer+"/"+strconv.Itoa(i))
+		}
+	case reflect.Struct:
+		t := v.Type()
+		if t == extensionPropsType {
+			return
+		}
+		if ref, value := v.FieldByName("Ref"), v.FieldByName("Value"); ref.IsValid() && value.IsValid() && t.NumField() == 2 {
+			// A reference, or the object it is inlined instead of
+			if ref.String() != "" {
+				if v.CanAddr() {
+					if r.loader.refOrigins == nil {
+						r.loader.refOrigins = make(map[interface{}]*Origin)
+					}
+					if origin := r.origin(pointer); origin != nil {
+						r.loader.refOrigins[v.Addr().Interface()] = origin
+					}
+				}
+				return
+			}
+			r.record(value, pointer)
+			return
+		}
+		if props := v.FieldByName("ExtensionProps"); props.IsValid() && props.Type() == extensionPropsType && props.CanSet() {
+			props.Addr().Interface().(*ExtensionProps).Origin = r.origin(pointer)
+		}
+		for _, field := range jsoninfo.GetTypeInfo(t).Fields {
+			if fv, ok := fieldByIndex(v, field.Index); ok {
+				r.record(fv, pointer+"/"+jsonpointer.Escape(field.JSONName))
+			}
+		}
+	}
+}
+
+// refOrigin returns the origin of a reference, if it was recorded.
+func (loader *Loader) refOrigin(component interface{}) *Origin {
+	return loader.refOrigins[component]
+}
@@ -0,0 +1,168 @@
+package openapi3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const originSpec = `openapi: 3.0.0
+info:
+  title: Pets
+  version: '1.0'
+paths:
+  /pets:
+    get:
+      parameters:
+      - $ref: 'parameters.yaml#/Limit'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          readOnly: true
+          writeOnly: true
+`
+
+func originLoader(files map[string]string) *Loader {
+	loader := NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.IncludeOrigin = true
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		if data, ok := files[location.Path]; ok {
+			return []byte(data), nil
+		}
+		return nil, fmt.Errorf("no file %s", location.Path)
+	}
+	return loader
+}
+
+func TestLoaderIncludeOrigin(t *testing.T) {
+	loader := originLoader(map[string]string{
+		"spec.yaml": originSpec,
+		"parameters.yaml": `Limit:
+  name: limit
+  in: query
+  schema:
+    type: integer
+`,
+	})
+	doc, err := loader.LoadFromFile("spec.yaml")
+	require.NoError(t, err)
+
+	operation := doc.Paths["/pets"].Get
+	require.Equal(t, &Origin{Document: "spec.yaml", Line: 7, Column: 5}, operation.Origin)
+	require.Equal(t, &Origin{Document: "parameters.yaml", Line: 1, Column: 1}, operation.Parameters[0].Value.Origin)
+	require.Equal(t, &Origin{Document: "parameters.yaml", Line: 4, Column: 3}, operation.Parameters[0].Value.Schema.Value.Origin)
+	require.Equal(t, &Origin{Document: "spec.yaml", Line: 19, Column: 5}, doc.Components.Schemas["Pet"].Value.Origin)
+	require.Equal(t, "spec.yaml:19:5", doc.Components.Schemas["Pet"].Value.Origin.String())
+
+	err = doc.Validate(context.Background())
+	require.EqualError(t, err, `invalid components: spec.yaml:22:9: a property MUST NOT be marked as both readOnly and writeOnly being true`)
+	err = doc.Components.Validate(context.Background())
+	var originErr *OriginError
+	require.True(t, errors.As(err, &originErr))
+	require.Equal(t, 22, originErr.Origin.Line)
+}
+
+func TestLoaderIncludeOriginRefError(t *testing.T) {
+	loader := originLoader(map[string]string{
+		"spec.yaml":       originSpec,
+		"parameters.yaml": "Offset:\n  name: offset\n  in: query\n",
+	})
+	_, err := loader.LoadFromFile("spec.yaml")
+	require.EqualError(t, err, `spec.yaml:9:7: map key "Limit" not found`)
+}
+
+func TestLoaderWithoutOrigin(t *testing.T) {
+	loader := NewLoader()
+	doc, err := loader.LoadFromData([]byte(originSpec[:len(`openapi: 3.0.0
+info:
+  title: Pets
+  version: '1.0'
+`)] + "paths: {}\n"))
+	require.NoError(t, err)
+	require.Nil(t, doc.Info.Origin)
+}
+
+func TestLoaderIncludeOriginReadsDocumentsOnce(t *testing.T) {
+	files := map[string]string{
+		"spec.yaml": `openapi: 3.0.0
+info: {title: Pets, version: '1.0'}
+paths:
+  /pets:
+    get:
+      parameters:
+      - $ref: 'parameters.yaml#/Limit'
+      - $ref: 'parameters.yaml#/Offset'
+      - $ref: '#/components/parameters/Sort'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema: {$ref: 'schemas.yaml#/Pets'}
+components:
+  parameters:
+    Sort: {name: sort, in: query, schema: {$ref: 'schemas.yaml#/Order'}}
+`,
+		"parameters.yaml": `Limit:
+  name: limit
+  in: query
+  schema: {$ref: 'schemas.yaml#/Count'}
+Offset:
+  name: offset
+  in: query
+  schema: {$ref: 'schemas.yaml#/Count'}
+`,
+		"schemas.yaml": `Count:
+  type: integer
+Order:
+  type: string
+Pets:
+  type: array
+  items: {$ref: '#/Pet'}
+Pet:
+  type: object
+`,
+	}
+	reads := make(map[string]int)
+	loader := originLoader(files)
+	read := loader.ReadFromURIFunc
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		reads[location.Path]++
+		return read(loader, location)
+	}
+	doc, err := loader.LoadFromFile("spec.yaml")
+	require.NoError(t, err)
+
+	parameters := doc.Paths["/pets"].Get.Parameters
+	require.Equal(t, &Origin{Document: "parameters.yaml", Line: 5, Column: 1}, parameters[1].Value.Origin)
+	require.Equal(t, &Origin{Document: "schemas.yaml", Line: 1, Column: 1}, parameters[1].Value.Schema.Value.Origin)
+
+	// Recording origins reads no document more often than loading it does
+	withoutOrigin := make(map[string]int)
+	loader = originLoader(files)
+	loader.IncludeOrigin = false
+	read = loader.ReadFromURIFunc
+	loader.ReadFromURIFunc = func(loader *Loader, location *url.URL) ([]byte, error) {
+		withoutOrigin[location.Path]++
+		return read(loader, location)
+	}
+	_, err = loader.LoadFromFile("spec.yaml")
+	require.NoError(t, err)
+	require.Equal(t, withoutOrigin, reads)
+}
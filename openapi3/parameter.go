@@ -245,7 +245,8 @@ func (parameter *Parameter) SerializationMethod() (*SerializationMethod, error)
 }
 
 // Validate returns an error if Parameter does not comply with the OpenAPI spec.
-func (parameter *Parameter) Validate(ctx context.Context) error {
+func (parameter *Parameter) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(parameter.Origin, err) }()
 	if parameter.Name == "" {
 		return errors.New("parameter name can't be blank")
 	}
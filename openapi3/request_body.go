@@ -104,7 +104,8 @@ func (requestBody *RequestBody) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if RequestBody does not comply with the OpenAPI spec.
-func (requestBody *RequestBody) Validate(ctx context.Context) error {
+func (requestBody *RequestBody) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(requestBody.Origin, err) }()
 	if requestBody.Content == nil {
 		return errors.New("content of the request body is required")
 	}
@@ -102,7 +102,8 @@ func (response *Response) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Response does not comply with the OpenAPI spec.
-func (response *Response) Validate(ctx context.Context) error {
+func (response *Response) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(response.Origin, err) }()
 	if response.Description == nil {
 		return errors.New("a short description of the response is required")
 	}
@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math"
 	"math/big"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"unicode/utf16"
 
 	"github.com/getkin/kin-openapi/jsoninfo"
@@ -599,6 +602,7 @@ func (schema *Schema) Validate(ctx context.Context) error {
 }
 
 func (schema *Schema) validate(ctx context.Context, stack []*Schema) (err error) {
+	defer func() { err = withOrigin(schema.Origin, err) }()
 	for _, existing := range stack {
 		if existing == schema {
 			return
@@ -781,7 +785,27 @@ func (schema *Schema) IsMatchingJSONObject(value map[string]interface{}) bool {
 
 func (schema *Schema) VisitJSON(value interface{}, opts ...SchemaValidationOption) error {
 	settings := newSchemaValidationSettings(opts...)
-	return schema.visitJSON(settings, value)
+	_, err := schema.visitJSONRoot(settings, value)
+	return err
+}
+
+// CoerceJSON validates value the way VisitJSON does with CoerceTypes
+// and returns value converted to the types declared by the schema.
+func (schema *Schema) CoerceJSON(value interface{}, opts ...SchemaValidationOption) (interface{}, error) {
+	settings := newSchemaValidationSettings(opts...)
+	settings.coerce = true
+	return schema.visitJSONRoot(settings, value)
+}
+
+func (schema *Schema) visitJSONRoot(settings *schemaValidationSettings, value interface{}) (interface{}, error) {
+	if settings.coerce {
+		value = schema.coerceJSON(value)
+	}
+	err := schema.visitJSON(settings, value)
+	if settings.location != "" {
+		err = markSchemaErrorLocation(err, settings.location)
+	}
+	return value, err
 }
 
 func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interface{}) (err error) {
@@ -809,6 +833,10 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 		return schema.visitJSONBoolean(settings, value)
 	case float64:
 		return schema.visitJSONNumber(settings, value)
+	case int64:
+		return schema.visitNumber(settings, value, integerNumber{new(big.Int).SetInt64(value)})
+	case uint64:
+		return schema.visitNumber(settings, value, integerNumber{new(big.Int).SetUint64(value)})
 	case string:
 		return schema.visitJSONString(settings, value)
 	case []interface{}:
@@ -825,6 +853,8 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 		if len(value) == len(values) {
 			return schema.visitJSONObject(settings, values)
 		}
+	case io.Reader: // binary data, such as the binary parts of multipart bodies
+		return schema.visitJSONBinary(settings, value)
 	}
 	return &SchemaError{
 		Value:       value,
@@ -835,9 +865,17 @@ func (schema *Schema) visitJSON(settings *schemaValidationSettings, value interf
 }
 
 func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, value interface{}) (err error) {
+	subSettings := settings
+	if len(schema.AllOf)+len(schema.OneOf)+len(schema.AnyOf) != 0 &&
+		(settings.asreq || settings.asrep || settings.rejectUnknown) {
+		subSettings = settings.withEnclosingProperties(schema)
+	}
+	altSettings := subSettings.forAlternatives()
+	allOfSettings := subSettings.forAllOf()
+
 	if enum := schema.Enum; len(enum) != 0 {
 		for _, v := range enum {
-			if value == v {
+			if value == v || numbersEqual(value, v) {
 				return
 			}
 		}
@@ -857,7 +895,7 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 		if v == nil {
 			return foundUnresolvedRef(ref.Ref)
 		}
-		if err := v.visitJSON(settings, value); err == nil {
+		if err := v.visitJSON(altSettings, value); err == nil {
 			if settings.failfast {
 				return errSchema
 			}
@@ -891,8 +929,8 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 		}
 
 		ok := 0
-		validationErrors := []error{}
-		for _, item := range v {
+		var branches, matched []*SchemaBranch
+		for i, item := range v {
 			v := item.Value
 			if v == nil {
 				return foundUnresolvedRef(item.Ref)
@@ -902,25 +940,17 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 				continue
 			}
 
-			if err := v.visitJSON(settings, value); err != nil {
-				validationErrors = append(validationErrors, err)
+			if err := v.visitJSON(altSettings, value); err != nil {
+				err = markSchemaErrorKeyword(err, item.Ref, "oneOf", strconv.Itoa(i))
+				branches = append(branches, &SchemaBranch{Index: i, Ref: item.Ref, Err: err})
 				continue
 			}
 
+			matched = append(matched, &SchemaBranch{Index: i, Ref: item.Ref})
 			ok++
 		}
 
 		if ok != 1 {
-			if len(validationErrors) > 1 {
-				errorMessage := ""
-				for _, err := range validationErrors {
-					if errorMessage != "" {
-						errorMessage += " Or "
-					}
-					errorMessage += err.Error()
-				}
-				return errors.New("doesn't match schema due to: " + errorMessage)
-			}
 			if settings.failfast {
 				return errSchema
 			}
@@ -931,8 +961,13 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 			}
 			if ok > 1 {
 				e.Origin = ErrOneOfConflict
-			} else if len(validationErrors) == 1 {
-				e.Origin = validationErrors[0]
+				e.Branches = matched
+			} else {
+				sortSchemaBranches(branches)
+				e.Branches = branches
+				if len(branches) == 1 {
+					e.Origin = branches[0].Err
+				}
 			}
 
 			return e
@@ -941,34 +976,40 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 
 	if v := schema.AnyOf; len(v) > 0 {
 		ok := false
-		for _, item := range v {
+		var branches []*SchemaBranch
+		for i, item := range v {
 			v := item.Value
 			if v == nil {
 				return foundUnresolvedRef(item.Ref)
 			}
-			if err := v.visitJSON(settings, value); err == nil {
-				ok = true
-				break
+			if err := v.visitJSON(altSettings, value); err != nil {
+				err = markSchemaErrorKeyword(err, item.Ref, "anyOf", strconv.Itoa(i))
+				branches = append(branches, &SchemaBranch{Index: i, Ref: item.Ref, Err: err})
+				continue
 			}
+			ok = true
+			break
 		}
 		if !ok {
 			if settings.failfast {
 				return errSchema
 			}
+			sortSchemaBranches(branches)
 			return &SchemaError{
 				Value:       value,
 				Schema:      schema,
 				SchemaField: "anyOf",
+				Branches:    branches,
 			}
 		}
 	}
 
-	for _, item := range schema.AllOf {
+	for i, item := range schema.AllOf {
 		v := item.Value
 		if v == nil {
 			return foundUnresolvedRef(item.Ref)
 		}
-		if err := v.visitJSON(settings, value); err != nil {
+		if err := v.visitJSON(allOfSettings, value); err != nil {
 			if settings.failfast {
 				return errSchema
 			}
@@ -976,7 +1017,7 @@ func (schema *Schema) visitSetOperations(settings *schemaValidationSettings, val
 				Value:       value,
 				Schema:      schema,
 				SchemaField: "allOf",
-				Origin:      err,
+				Origin:      markSchemaErrorKeyword(err, item.Ref, "allOf", strconv.Itoa(i)),
 			}
 		}
 	}
@@ -1016,10 +1057,15 @@ func (schema *Schema) VisitJSONNumber(value float64) error {
 }
 
 func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value float64) error {
+	return schema.visitNumber(settings, value, floatNumber(value))
+}
+
+// visitNumber validates a number, which is value in errors.
+func (schema *Schema) visitNumber(settings *schemaValidationSettings, value interface{}, n number) error {
 	var me MultiError
 	schemaType := schema.Type
 	if schemaType == "integer" {
-		if bigFloat := big.NewFloat(value); !bigFloat.IsInt() {
+		if !n.isInt() {
 			if settings.failfast {
 				return errSchema
 			}
@@ -1039,7 +1085,7 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 	}
 
 	// "exclusiveMinimum"
-	if v := schema.ExclusiveMin; v && !(*schema.Min < value) {
+	if v := schema.ExclusiveMin; v && !(n.cmp(*schema.Min) > 0) {
 		if settings.failfast {
 			return errSchema
 		}
@@ -1056,7 +1102,7 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 	}
 
 	// "exclusiveMaximum"
-	if v := schema.ExclusiveMax; v && !(*schema.Max > value) {
+	if v := schema.ExclusiveMax; v && !(n.cmp(*schema.Max) < 0) {
 		if settings.failfast {
 			return errSchema
 		}
@@ -1073,7 +1119,7 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 	}
 
 	// "minimum"
-	if v := schema.Min; v != nil && !(*v <= value) {
+	if v := schema.Min; v != nil && !(n.cmp(*v) >= 0) {
 		if settings.failfast {
 			return errSchema
 		}
@@ -1090,7 +1136,7 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 	}
 
 	// "maximum"
-	if v := schema.Max; v != nil && !(*v >= value) {
+	if v := schema.Max; v != nil && !(n.cmp(*v) <= 0) {
 		if settings.failfast {
 			return errSchema
 		}
@@ -1110,7 +1156,7 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 	if v := schema.MultipleOf; v != nil {
 		// "A numeric instance is valid only if division by this keyword's
 		//    value results in an integer."
-		if bigFloat := big.NewFloat(value / *v); !bigFloat.IsInt() {
+		if !n.isMultipleOf(*v) {
 			if settings.failfast {
 				return errSchema
 			}
@@ -1133,11 +1179,126 @@ func (schema *Schema) visitJSONNumber(settings *schemaValidationSettings, value
 	return nil
 }
 
+// number is a number validated against a schema: a float64, as encoding/json decodes numbers,
+// or an integer that a float64 cannot hold exactly.
+type number interface {
+	isInt() bool
+	// cmp compares the number with the value of a keyword, returning -1, 0 or +1.
+	cmp(x float64) int
+	isMultipleOf(x float64) bool
+}
+
+type floatNumber float64
+
+func (n floatNumber) isInt() bool {
+	return big.NewFloat(float64(n)).IsInt()
+}
+
+func (n floatNumber) cmp(x float64) int {
+	switch {
+	case float64(n) < x:
+		return -1
+	case float64(n) > x:
+		return 1
+	}
+	return 0
+}
+
+func (n floatNumber) isMultipleOf(x float64) bool {
+	return big.NewFloat(float64(n) / x).IsInt()
+}
+
+// integerNumber is an integer that is compared exactly, such as an int64 or uint64 beyond 2^53.
+type integerNumber struct {
+	*big.Int
+}
+
+func (n integerNumber) isInt() bool {
+	return true
+}
+
+func (n integerNumber) cmp(x float64) int {
+	return new(big.Float).SetInt(n.Int).Cmp(big.NewFloat(x))
+}
+
+func (n integerNumber) isMultipleOf(x float64) bool {
+	m := new(big.Rat)
+	if x == 0 || math.IsInf(x, 0) || math.IsNaN(x) || m.SetFloat64(x) == nil {
+		return false
+	}
+	return new(big.Rat).Quo(new(big.Rat).SetInt(n.Int), m).IsInt()
+}
+
+// numbersEqual tells whether an integer that a float64 cannot hold exactly, such as one of the values
+// VisitValue validates, equals the number of an enum, which encoding/json decodes to a float64.
+func numbersEqual(value, enum interface{}) bool {
+	x, ok := enum.(float64)
+	if !ok || math.IsInf(x, 0) || math.IsNaN(x) {
+		return false
+	}
+	switch v := value.(type) {
+	case int64:
+		return integerNumber{new(big.Int).SetInt64(v)}.cmp(x) == 0
+	case uint64:
+		return integerNumber{new(big.Int).SetUint64(v)}.cmp(x) == 0
+	}
+	return false
+}
+
 func (schema *Schema) VisitJSONString(value string) error {
 	settings := newSchemaValidationSettings()
 	return schema.visitJSONString(settings, value)
 }
 
+// visitJSONBinary validates binary data as a string of its bytes. Only the length of the data is validated,
+// if its reader has a Size method, as *bytes.Reader does: its content is not read.
+func (schema *Schema) visitJSONBinary(settings *schemaValidationSettings, value io.Reader) error {
+	if schemaType := schema.Type; schemaType != "" && schemaType != TypeString {
+		return schema.expectedType(settings, TypeString)
+	}
+	sized, ok := value.(interface{ Size() int64 })
+	if !ok {
+		return nil
+	}
+	length := sized.Size()
+
+	var me MultiError
+	if minLength := schema.MinLength; minLength != 0 && length < int64(minLength) {
+		if settings.failfast {
+			return errSchema
+		}
+		err := &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "minLength",
+			Reason:      fmt.Sprintf("minimum binary length is %d", minLength),
+		}
+		if !settings.multiError {
+			return err
+		}
+		me = append(me, err)
+	}
+	if maxLength := schema.MaxLength; maxLength != nil && length > int64(*maxLength) {
+		if settings.failfast {
+			return errSchema
+		}
+		err := &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "maxLength",
+			Reason:      fmt.Sprintf("maximum binary length is %d", *maxLength),
+		}
+		if !settings.multiError {
+			return err
+		}
+		me = append(me, err)
+	}
+	if len(me) > 0 {
+		return me
+	}
+	return nil
+}
+
 func (schema *Schema) visitJSONString(settings *schemaValidationSettings, value string) error {
 	if schemaType := schema.Type; schemaType != "" && schemaType != TypeString {
 		return schema.expectedType(settings, TypeString)
@@ -1326,8 +1487,14 @@ func (schema *Schema) visitJSONArray(settings *schemaValidationSettings, value [
 		if itemSchema == nil {
 			return foundUnresolvedRef(itemSchemaRef.Ref)
 		}
+		itemSettings := settings.forSubschemas()
 		for i, item := range value {
-			if err := itemSchema.visitJSON(settings, item); err != nil {
+			if settings.coerce {
+				item = itemSchema.coerceJSON(item)
+				value[i] = item
+			}
+			if err := itemSchema.visitJSON(itemSettings, item); err != nil {
+				err = markSchemaErrorKeyword(err, itemSchemaRef.Ref, "items")
 				err = markSchemaErrorIndex(err, i)
 				if !settings.multiError {
 					return err
@@ -1403,6 +1570,8 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 	if ref := schema.AdditionalProperties; ref != nil {
 		additionalProperties = ref.Value
 	}
+	propertySettings := settings.forSubschemas()
+	var declared *declaredProperties
 	for k, v := range value {
 		if properties != nil {
 			propertyRef := properties[k]
@@ -1411,10 +1580,27 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 				if p == nil {
 					return foundUnresolvedRef(propertyRef.Ref)
 				}
-				if err := p.visitJSON(settings, v); err != nil {
+				if settings.coerce {
+					v = p.coerceJSON(v)
+					value[k] = v
+				}
+				if err := p.visitReadOnlyWriteOnly(settings, k, v); err != nil {
+					if settings.failfast {
+						return errSchema
+					}
+					err = markSchemaErrorKeyword(err, propertyRef.Ref, "properties", k)
+					err = markSchemaErrorKey(err, k)
+					if !settings.multiError {
+						return err
+					}
+					me = append(me, err)
+					continue
+				}
+				if err := p.visitJSON(propertySettings, v); err != nil {
 					if settings.failfast {
 						return errSchema
 					}
+					err = markSchemaErrorKeyword(err, propertyRef.Ref, "properties", k)
 					err = markSchemaErrorKey(err, k)
 					if !settings.multiError {
 						return err
@@ -1429,12 +1615,38 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 			}
 		}
 		allowed := schema.AdditionalPropertiesAllowed
+		if additionalProperties == nil && allowed == nil && settings.rejectUnknown && settings.enclosingProperties == nil {
+			if declared == nil {
+				declared = schema.declaredProperties()
+			}
+			if _, ok := declared.properties[k]; !ok && !declared.open && len(declared.properties) != 0 {
+				if settings.failfast {
+					return errSchema
+				}
+				err := markSchemaErrorKey(&SchemaError{
+					Value:       v,
+					Schema:      schema,
+					SchemaField: "additionalProperties",
+					Reason:      fmt.Sprintf("property %q is unsupported", k),
+				}, k)
+				if !settings.multiError {
+					return err
+				}
+				me = append(me, err)
+			}
+			continue
+		}
 		if additionalProperties != nil || allowed == nil || *allowed {
 			if additionalProperties != nil {
-				if err := additionalProperties.visitJSON(settings, v); err != nil {
+				if settings.coerce {
+					v = additionalProperties.coerceJSON(v)
+					value[k] = v
+				}
+				if err := additionalProperties.visitJSON(propertySettings, v); err != nil {
 					if settings.failfast {
 						return errSchema
 					}
+					err = markSchemaErrorKeyword(err, schema.AdditionalProperties.Ref, "additionalProperties")
 					err = markSchemaErrorKey(err, k)
 					if !settings.multiError {
 						return err
@@ -1448,6 +1660,10 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 			}
 			continue
 		}
+		if settings.rejectUnknown && settings.inAllOf {
+			// Left to the schema holding this "allOf" subschema.
+			continue
+		}
 		if settings.failfast {
 			return errSchema
 		}
@@ -1466,11 +1682,17 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 	// "required"
 	for _, k := range schema.Required {
 		if _, ok := value[k]; !ok {
-			if s := schema.Properties[k]; s != nil && s.Value.ReadOnly && settings.asreq {
-				continue
+			s := schema.Properties[k]
+			if s == nil {
+				s = settings.enclosingProperties[k]
 			}
-			if s := schema.Properties[k]; s != nil && s.Value.WriteOnly && settings.asrep {
-				continue
+			if s != nil && s.Value != nil {
+				if s.Value.ReadOnly && settings.asreq {
+					continue
+				}
+				if s.Value.WriteOnly && settings.asrep {
+					continue
+				}
 			}
 			if settings.failfast {
 				return errSchema
@@ -1495,6 +1717,65 @@ func (schema *Schema) visitJSONObject(settings *schemaValidationSettings, value
 	return nil
 }
 
+// declaredProperties are the properties an object may have.
+type declaredProperties struct {
+	properties Schemas
+	// open is set when some schema allows additional properties explicitly.
+	open bool
+}
+
+// declaredProperties returns the properties declared by the schema
+// and by its "allOf", "oneOf" and "anyOf" subschemas.
+func (schema *Schema) declaredProperties() *declaredProperties {
+	declared := &declaredProperties{properties: make(Schemas)}
+	declared.collect(schema, make(map[*Schema]struct{}))
+	return declared
+}
+
+func (declared *declaredProperties) collect(schema *Schema, visited map[*Schema]struct{}) {
+	if _, ok := visited[schema]; ok {
+		return
+	}
+	visited[schema] = struct{}{}
+	for k, v := range schema.Properties {
+		if _, ok := declared.properties[k]; !ok {
+			declared.properties[k] = v
+		}
+	}
+	if schema.AdditionalProperties != nil || (schema.AdditionalPropertiesAllowed != nil && *schema.AdditionalPropertiesAllowed) {
+		declared.open = true
+	}
+	for _, refs := range []SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, item := range refs {
+			if item.Value != nil {
+				declared.collect(item.Value, visited)
+			}
+		}
+	}
+}
+
+// visitReadOnlyWriteOnly rejects write-only properties found in responses
+// and, if asked to, read-only properties found in requests.
+func (schema *Schema) visitReadOnlyWriteOnly(settings *schemaValidationSettings, key string, value interface{}) error {
+	switch {
+	case schema.ReadOnly && settings.asreq && settings.rejectReadOnly:
+		return &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "readOnly",
+			Reason:      fmt.Sprintf("readOnly property %q in request", key),
+		}
+	case schema.WriteOnly && settings.asrep:
+		return &SchemaError{
+			Value:       value,
+			Schema:      schema,
+			SchemaField: "writeOnly",
+			Reason:      fmt.Sprintf("writeOnly property %q in response", key),
+		}
+	}
+	return nil
+}
+
 func (schema *Schema) expectedType(settings *schemaValidationSettings, typ string) error {
 	if settings.failfast {
 		return errSchema
@@ -1525,13 +1806,94 @@ type SchemaError struct {
 	SchemaField string
 	Reason      string
 	Origin      error
+	// reverseKeywordPath leads from the validated schema to Schema, through "$ref"s.
+	reverseKeywordPath []string
+	// absoluteRef is the innermost "$ref" followed to reach Schema and
+	// reverseAbsolutePath leads from it to Schema.
+	absoluteRef         string
+	reverseAbsolutePath []string
+	// Branches holds the outcome of validating against each "oneOf" or "anyOf"
+	// candidate when SchemaField is one of these keywords. If no candidate
+	// matched, branches are ranked from the closest to the furthest match.
+	// If more than one "oneOf" candidate matched, only those are listed.
+	Branches []*SchemaBranch
 }
 
 var _ interface{ Unwrap() error } = SchemaError{}
 
+// SchemaBranch is the outcome of validating a value against one of
+// the candidates of a "oneOf" or "anyOf" schema.
+type SchemaBranch struct {
+	// Index is the position of the candidate in the "oneOf" or "anyOf" list.
+	Index int
+	// Ref is the candidate's $ref, if it has one.
+	Ref string
+	// Err is nil when the value matches the candidate.
+	Err error
+}
+
+// Name returns the candidate's $ref or, failing that, its position.
+func (branch *SchemaBranch) Name() string {
+	if branch.Ref != "" {
+		return branch.Ref
+	}
+	return "#" + strconv.Itoa(branch.Index)
+}
+
+// ClosestBranch returns the candidate that came closest to matching a value
+// that matched no "oneOf" or "anyOf" candidate, or nil.
+func (err *SchemaError) ClosestBranch() *SchemaBranch {
+	if len(err.Branches) == 0 || err.Branches[0].Err == nil {
+		return nil
+	}
+	return err.Branches[0]
+}
+
+// sortSchemaBranches ranks failed candidates by how far validation got:
+// errors found deeper into the value come first, then fewer errors.
+func sortSchemaBranches(branches []*SchemaBranch) {
+	sort.SliceStable(branches, func(i, j int) bool {
+		di, ci := schemaErrorRank(branches[i].Err)
+		dj, cj := schemaErrorRank(branches[j].Err)
+		if di != dj {
+			return di > dj
+		}
+		return ci < cj
+	})
+}
+
+// schemaErrorRank returns the depth of the deepest error along with the number of errors.
+func schemaErrorRank(err error) (depth, count int) {
+	switch e := err.(type) {
+	case *SchemaError:
+		depth, count = len(e.reversePath), 1
+		if closest := e.ClosestBranch(); closest != nil {
+			d, c := schemaErrorRank(closest.Err)
+			depth, count = depth+d, c
+		} else if e.Origin != nil {
+			d, c := schemaErrorRank(e.Origin)
+			depth, count = depth+d, c
+		}
+	case MultiError:
+		for _, item := range e {
+			d, c := schemaErrorRank(item)
+			if d > depth {
+				depth = d
+			}
+			count += c
+		}
+	default:
+		count = 1
+	}
+	return
+}
+
 func markSchemaErrorKey(err error, key string) error {
 	if v, ok := err.(*SchemaError); ok {
 		v.reversePath = append(v.reversePath, key)
+		for _, e := range v.nested() {
+			_ = markSchemaErrorKey(e, key)
+		}
 		return v
 	}
 	if v, ok := err.(MultiError); ok {
@@ -1546,6 +1908,9 @@ func markSchemaErrorKey(err error, key string) error {
 func markSchemaErrorIndex(err error, index int) error {
 	if v, ok := err.(*SchemaError); ok {
 		v.reversePath = append(v.reversePath, strconv.FormatInt(int64(index), 10))
+		for _, e := range v.nested() {
+			_ = markSchemaErrorIndex(e, index)
+		}
 		return v
 	}
 	if v, ok := err.(MultiError); ok {
@@ -1557,6 +1922,128 @@ func markSchemaErrorIndex(err error, index int) error {
 	return err
 }
 
+// markSchemaErrorKeyword records that err was found while validating
+// against the schema at tokens, itself reached through ref if not empty.
+func markSchemaErrorKeyword(err error, ref string, tokens ...string) error {
+	if v, ok := err.(*SchemaError); ok {
+		if ref != "" {
+			v.reverseKeywordPath = append(v.reverseKeywordPath, "$ref")
+		}
+		for i := len(tokens) - 1; i >= 0; i-- {
+			v.reverseKeywordPath = append(v.reverseKeywordPath, tokens[i])
+		}
+		if v.absoluteRef == "" {
+			if ref != "" {
+				v.absoluteRef = ref
+			} else {
+				for i := len(tokens) - 1; i >= 0; i-- {
+					v.reverseAbsolutePath = append(v.reverseAbsolutePath, tokens[i])
+				}
+			}
+		}
+		for _, e := range v.nested() {
+			_ = markSchemaErrorKeyword(e, ref, tokens...)
+		}
+		return v
+	}
+	if v, ok := err.(MultiError); ok {
+		for _, e := range v {
+			_ = markSchemaErrorKeyword(e, ref, tokens...)
+		}
+		return v
+	}
+	return err
+}
+
+// markSchemaErrorLocation records where the validated schema lives, for errors
+// that did not go through a "$ref" on the way to their schema.
+func markSchemaErrorLocation(err error, location string) error {
+	if v, ok := err.(*SchemaError); ok {
+		if v.absoluteRef == "" {
+			v.absoluteRef = location
+		}
+		for _, e := range v.nested() {
+			_ = markSchemaErrorLocation(e, location)
+		}
+		return v
+	}
+	if v, ok := err.(MultiError); ok {
+		for _, e := range v {
+			_ = markSchemaErrorLocation(e, location)
+		}
+		return v
+	}
+	return err
+}
+
+// nested returns the errors found while validating against subschemas.
+func (err *SchemaError) nested() []error {
+	if len(err.Branches) != 0 {
+		errs := make([]error, 0, len(err.Branches))
+		for _, branch := range err.Branches {
+			if branch.Err != nil {
+				errs = append(errs, branch.Err)
+			}
+		}
+		return errs
+	}
+	if err.Origin != nil {
+		return []error{err.Origin}
+	}
+	return nil
+}
+
+// InstanceLocation returns the JSON pointer to the invalid value.
+func (err *SchemaError) InstanceLocation() string {
+	return reverseJSONPointer(err.reversePath)
+}
+
+// KeywordLocation returns the JSON pointer to the failing keyword, relative to
+// the validated schema and following "$ref"s, e.g. "/properties/pet/$ref/required".
+func (err *SchemaError) KeywordLocation() string {
+	return reverseJSONPointer(append([]string{err.SchemaField}, err.reverseKeywordPath...))
+}
+
+// AbsoluteKeywordLocation returns the location of the failing keyword within
+// the document defining the innermost "$ref" followed to reach it,
+// e.g. "#/components/schemas/Pet/properties/name/maxLength".
+// It is empty when no "$ref" was followed.
+func (err *SchemaError) AbsoluteKeywordLocation() string {
+	ref := err.absoluteRef
+	if ref == "" {
+		return ""
+	}
+	if !strings.Contains(ref, "#") {
+		ref += "#"
+	}
+	return ref + reverseJSONPointer(append([]string{err.SchemaField}, err.reverseAbsolutePath...))
+}
+
+// ComponentName returns the name of the component schema holding the failing
+// keyword, if it was reached through a "$ref" to "#/components/schemas/...".
+func (err *SchemaError) ComponentName() string {
+	const prefix = "#/components/schemas/"
+	ref := err.absoluteRef
+	i := strings.Index(ref, prefix)
+	if i < 0 {
+		return ""
+	}
+	name := ref[i+len(prefix):]
+	if j := strings.IndexByte(name, '/'); j >= 0 {
+		name = name[:j]
+	}
+	return jsonpointer.Unescape(name)
+}
+
+func reverseJSONPointer(reversePath []string) string {
+	buf := bytes.NewBuffer(make([]byte, 0, 64))
+	for i := len(reversePath) - 1; i >= 0; i-- {
+		buf.WriteByte('/')
+		buf.WriteString(jsonpointer.Escape(reversePath[i]))
+	}
+	return buf.String()
+}
+
 func (err *SchemaError) JSONPointer() []string {
 	reversePath := err.reversePath
 	path := append([]string(nil), reversePath...)
@@ -1571,6 +2058,17 @@ func (err *SchemaError) Error() string {
 		return err.Origin.Error()
 	}
 
+	if err.SchemaField == "oneOf" && len(err.Branches) > 1 && err.Reason == "" {
+		buf := bytes.NewBufferString("doesn't match schema due to: ")
+		for i, branch := range err.Branches {
+			if i != 0 {
+				buf.WriteString(" Or ")
+			}
+			buf.WriteString(branch.Err.Error())
+		}
+		return buf.String()
+	}
+
 	buf := bytes.NewBuffer(make([]byte, 0, 256))
 	if len(err.reversePath) > 0 {
 		buf.WriteString(`Error at "`)
@@ -0,0 +1,82 @@
+package openapi3
+
+import (
+	"strconv"
+)
+
+// CoerceValue converts value to the type declared by the schema:
+// strings to numbers, integers and booleans, single values to
+// one-element arrays and null to the schema's default.
+// Values that do not need converting are returned as is.
+// The function returns an error when a string cannot be parsed
+// as the declared type. Nested values are left untouched.
+func (schema *Schema) CoerceValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		if !schema.Nullable && schema.Default != nil {
+			return schema.Default, nil
+		}
+		return nil, nil
+	}
+
+	switch schema.coercionType() {
+	case TypeInteger, TypeNumber:
+		if s, ok := value.(string); ok {
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+	case TypeBoolean:
+		if s, ok := value.(string); ok {
+			v, err := strconv.ParseBool(s)
+			if err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+	case TypeArray:
+		if _, ok := value.([]interface{}); !ok {
+			return []interface{}{value}, nil
+		}
+	}
+	return value, nil
+}
+
+// coerceJSON converts value with CoerceValue,
+// leaving it to validation to report values that cannot be converted.
+func (schema *Schema) coerceJSON(value interface{}) interface{} {
+	v, err := schema.CoerceValue(value)
+	if err != nil {
+		return value
+	}
+	return v
+}
+
+// coercionType returns the type declared by the schema or by its "allOf" subschemas.
+func (schema *Schema) coercionType() string {
+	if schema.Type != "" || len(schema.AllOf) == 0 {
+		return schema.Type
+	}
+	return schema.allOfType(make(map[*Schema]struct{}))
+}
+
+// allOfType returns the type declared by the schema or by its "allOf" subschemas,
+// skipping the visited schemas of "allOf" cycles.
+func (schema *Schema) allOfType(visited map[*Schema]struct{}) string {
+	if schema.Type != "" {
+		return schema.Type
+	}
+	if _, ok := visited[schema]; ok {
+		return ""
+	}
+	visited[schema] = struct{}{}
+	for _, item := range schema.AllOf {
+		if v := item.Value; v != nil {
+			if typ := v.allOfType(visited); typ != "" {
+				return typ
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,96 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCoerceValue(t *testing.T) {
+	for _, tc := range []struct {
+		schema *Schema
+		value  interface{}
+		want   interface{}
+		err    bool
+	}{
+		{schema: NewIntegerSchema(), value: "42", want: 42.0},
+		{schema: NewFloat64Schema(), value: "4.2", want: 4.2},
+		{schema: NewFloat64Schema(), value: "four", err: true},
+		{schema: NewBoolSchema(), value: "true", want: true},
+		{schema: NewBoolSchema(), value: "yes", err: true},
+		{schema: NewStringSchema(), value: "42", want: "42"},
+		{schema: NewArraySchema().WithItems(NewStringSchema()), value: "a", want: []interface{}{"a"}},
+		{schema: NewArraySchema().WithItems(NewStringSchema()), value: []interface{}{"a"}, want: []interface{}{"a"}},
+		{schema: &Schema{Type: TypeString, Default: "none"}, value: nil, want: "none"},
+		{schema: &Schema{Type: TypeString, Default: "none", Nullable: true}, value: nil, want: nil},
+		{schema: &Schema{AllOf: SchemaRefs{NewSchemaRef("", NewIntegerSchema())}}, value: "42", want: 42.0},
+	} {
+		got, err := tc.schema.CoerceValue(tc.value)
+		if tc.err {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.want, got)
+	}
+}
+
+func TestSchemaCoerceValueAllOfCycle(t *testing.T) {
+	a, b := &Schema{}, &Schema{}
+	a.AllOf = SchemaRefs{NewSchemaRef("#/components/schemas/B", b)}
+	b.AllOf = SchemaRefs{NewSchemaRef("#/components/schemas/A", a), NewSchemaRef("", NewBoolSchema())}
+
+	got, err := a.CoerceValue("true")
+	require.NoError(t, err)
+	require.Equal(t, true, got)
+
+	b.AllOf = b.AllOf[:1]
+	got, err = a.CoerceValue("true")
+	require.NoError(t, err)
+	require.Equal(t, "true", got)
+}
+
+func TestSchemaCoerceJSON(t *testing.T) {
+	schema := NewObjectSchema().
+		WithProperty("count", NewIntegerSchema()).
+		WithProperty("enabled", NewBoolSchema()).
+		WithProperty("tags", NewArraySchema().WithItems(NewIntegerSchema())).
+		WithProperty("mode", &Schema{Type: TypeString, Default: "auto"}).
+		WithProperty("either", &Schema{OneOf: SchemaRefs{
+			NewSchemaRef("", NewIntegerSchema()),
+			NewSchemaRef("", NewStringSchema()),
+		}})
+	schema.AdditionalProperties = NewSchemaRef("", NewFloat64Schema())
+
+	value := map[string]interface{}{
+		"count":   "42",
+		"enabled": "false",
+		"tags":    "7",
+		"mode":    nil,
+		"either":  "1",
+		"ratio":   "0.5",
+	}
+	require.Error(t, schema.VisitJSON(map[string]interface{}{"count": "42"}))
+
+	got, err := schema.CoerceJSON(value)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"count":   42.0,
+		"enabled": false,
+		"tags":    []interface{}{7.0},
+		"mode":    "auto",
+		"either":  "1",
+		"ratio":   0.5,
+	}, got)
+
+	_, err = schema.CoerceJSON(map[string]interface{}{"count": "many"})
+	require.Error(t, err)
+
+	got, err = NewArraySchema().WithItems(NewIntegerSchema()).CoerceJSON("1")
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{1.0}, got)
+
+	value = map[string]interface{}{"count": "42"}
+	require.NoError(t, schema.VisitJSON(value, CoerceTypes()))
+	require.Equal(t, map[string]interface{}{"count": 42.0}, value)
+}
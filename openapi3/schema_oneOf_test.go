@@ -134,3 +134,45 @@ func TestVisitJSON_OneOf_BadDescriminatorType(t *testing.T) {
 	})
 	require.EqualError(t, err, "descriminator value is not a string")
 }
+
+func TestVisitJSON_OneOf_ConflictBranches(t *testing.T) {
+	s, err := NewLoader().LoadFromData(oneofNoDiscriminatorSpec)
+	require.NoError(t, err)
+	err = s.Components.Schemas["Animal"].Value.VisitJSON(map[string]interface{}{
+		"name":      "snoopy",
+		"barks":     true,
+		"scratches": true,
+	})
+	require.EqualError(t, err, ErrOneOfConflict.Error())
+
+	schemaErr, ok := err.(*SchemaError)
+	require.True(t, ok)
+	require.Nil(t, schemaErr.ClosestBranch())
+	require.Len(t, schemaErr.Branches, 2)
+	require.Equal(t, "#/components/schemas/Cat", schemaErr.Branches[0].Name())
+	require.Equal(t, "#/components/schemas/Dog", schemaErr.Branches[1].Name())
+}
+
+func TestVisitJSON_OneOf_ClosestBranch(t *testing.T) {
+	schema := NewObjectSchema().WithProperty("pet", &Schema{
+		OneOf: SchemaRefs{
+			NewSchemaRef("", NewIntegerSchema()),
+			NewSchemaRef("", NewObjectSchema().WithProperty("name", NewStringSchema())),
+		},
+	})
+	err := schema.VisitJSON(map[string]interface{}{
+		"pet": map[string]interface{}{"name": 42.0},
+	})
+	require.Error(t, err)
+
+	schemaErr, ok := err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, []string{"pet"}, schemaErr.JSONPointer())
+
+	closest := schemaErr.ClosestBranch()
+	require.NotNil(t, closest)
+	require.Equal(t, "#1", closest.Name())
+	closestErr, ok := closest.Err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, []string{"pet", "name"}, closestErr.JSONPointer())
+}
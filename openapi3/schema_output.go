@@ -0,0 +1,104 @@
+package openapi3
+
+// SchemaErrorOutput is an output unit of the JSON Schema "basic" and "detailed" output formats.
+// See https://json-schema.org/draft/2020-12/json-schema-core.html#name-output-formatting
+type SchemaErrorOutput struct {
+	Valid                   bool                 `json:"valid"`
+	KeywordLocation         string               `json:"keywordLocation"`
+	AbsoluteKeywordLocation string               `json:"absoluteKeywordLocation,omitempty"`
+	InstanceLocation        string               `json:"instanceLocation"`
+	Error                   string               `json:"error,omitempty"`
+	Errors                  []*SchemaErrorOutput `json:"errors,omitempty"`
+}
+
+// NewBasicOutput describes an error returned by Schema.VisitJSON
+// as a flat list of output units, in the "basic" output format.
+func NewBasicOutput(err error) *SchemaErrorOutput {
+	if err == nil {
+		return &SchemaErrorOutput{Valid: true}
+	}
+	return &SchemaErrorOutput{Errors: basicOutputUnits(err, nil)}
+}
+
+// NewDetailedOutput describes an error returned by Schema.VisitJSON
+// as a tree of output units following the schema structure,
+// in the "detailed" output format.
+func NewDetailedOutput(err error) *SchemaErrorOutput {
+	if err == nil {
+		return &SchemaErrorOutput{Valid: true}
+	}
+	if me, ok := err.(MultiError); ok {
+		units := make([]*SchemaErrorOutput, 0, len(me))
+		for _, e := range me {
+			units = append(units, detailedOutputUnit(e, nil))
+		}
+		return &SchemaErrorOutput{Errors: units}
+	}
+	return &SchemaErrorOutput{Errors: []*SchemaErrorOutput{detailedOutputUnit(err, nil)}}
+}
+
+func basicOutputUnits(err error, parent *SchemaErrorOutput) []*SchemaErrorOutput {
+	if me, ok := err.(MultiError); ok {
+		var units []*SchemaErrorOutput
+		for _, e := range me {
+			units = append(units, basicOutputUnits(e, parent)...)
+		}
+		return units
+	}
+	unit := newOutputUnit(err, parent)
+	units := []*SchemaErrorOutput{unit}
+	if v, ok := err.(*SchemaError); ok {
+		for _, e := range v.nested() {
+			units = append(units, basicOutputUnits(e, unit)...)
+		}
+	}
+	return units
+}
+
+func detailedOutputUnit(err error, parent *SchemaErrorOutput) *SchemaErrorOutput {
+	unit := newOutputUnit(err, parent)
+	if v, ok := err.(*SchemaError); ok {
+		for _, e := range v.nested() {
+			if me, ok := e.(MultiError); ok {
+				for _, e := range me {
+					unit.Errors = append(unit.Errors, detailedOutputUnit(e, unit))
+				}
+				continue
+			}
+			unit.Errors = append(unit.Errors, detailedOutputUnit(e, unit))
+		}
+	}
+	return unit
+}
+
+// newOutputUnit describes a single error. Errors that are not
+// a *SchemaError take their locations from their parent.
+func newOutputUnit(err error, parent *SchemaErrorOutput) *SchemaErrorOutput {
+	v, ok := err.(*SchemaError)
+	if !ok {
+		unit := &SchemaErrorOutput{Error: err.Error()}
+		if parent != nil {
+			unit.KeywordLocation = parent.KeywordLocation
+			unit.AbsoluteKeywordLocation = parent.AbsoluteKeywordLocation
+			unit.InstanceLocation = parent.InstanceLocation
+		}
+		return unit
+	}
+	return &SchemaErrorOutput{
+		KeywordLocation:         v.KeywordLocation(),
+		AbsoluteKeywordLocation: v.AbsoluteKeywordLocation(),
+		InstanceLocation:        v.InstanceLocation(),
+		Error:                   v.reason(),
+	}
+}
+
+// reason describes the error without its nested errors nor any details.
+func (err *SchemaError) reason() string {
+	if err.Reason != "" {
+		return err.Reason
+	}
+	if err.Origin == ErrOneOfConflict {
+		return ErrOneOfConflict.Error()
+	}
+	return `Doesn't match schema "` + err.SchemaField + `"`
+}
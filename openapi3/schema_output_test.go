@@ -0,0 +1,153 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var outputSpec = []byte(`
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Name:
+      type: string
+      maxLength: 5
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          $ref: "#/components/schemas/Name"
+        tags:
+          type: array
+          items:
+            type: string
+    Owner:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: "#/components/schemas/Pet"
+`)
+
+func TestSchemaErrorLocations(t *testing.T) {
+	doc, err := NewLoader().LoadFromData(outputSpec)
+	require.NoError(t, err)
+	owner := doc.Components.Schemas["Owner"].Value
+
+	err = owner.VisitJSON(map[string]interface{}{
+		"pets": []interface{}{
+			map[string]interface{}{"name": "Snoopy"},
+		},
+	}, SchemaLocation("#/components/schemas/Owner"))
+	require.Error(t, err)
+	schemaErr, ok := err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/pets/0/name", schemaErr.InstanceLocation())
+	require.Equal(t, "/properties/pets/items/$ref/properties/name/$ref/maxLength", schemaErr.KeywordLocation())
+	require.Equal(t, "#/components/schemas/Name/maxLength", schemaErr.AbsoluteKeywordLocation())
+	require.Equal(t, "Name", schemaErr.ComponentName())
+
+	err = owner.VisitJSON(map[string]interface{}{
+		"pets": []interface{}{
+			map[string]interface{}{"name": "Rex", "tags": []interface{}{"a", true}},
+		},
+	}, SchemaLocation("#/components/schemas/Owner"))
+	require.Error(t, err)
+	schemaErr, ok = err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/pets/0/tags/1", schemaErr.InstanceLocation())
+	require.Equal(t, "/properties/pets/items/$ref/properties/tags/items/type", schemaErr.KeywordLocation())
+	require.Equal(t, "#/components/schemas/Pet/properties/tags/items/type", schemaErr.AbsoluteKeywordLocation())
+	require.Equal(t, "Pet", schemaErr.ComponentName())
+
+	err = owner.VisitJSON(map[string]interface{}{"pets": "none"}, SchemaLocation("#/components/schemas/Owner"))
+	require.Error(t, err)
+	schemaErr, ok = err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "#/components/schemas/Owner/properties/pets/type", schemaErr.AbsoluteKeywordLocation())
+	require.Equal(t, "Owner", schemaErr.ComponentName())
+}
+
+func TestSchemaErrorOutput(t *testing.T) {
+	doc, err := NewLoader().LoadFromData(outputSpec)
+	require.NoError(t, err)
+	pet := doc.Components.Schemas["Pet"].Value
+
+	require.Equal(t, &SchemaErrorOutput{Valid: true}, NewBasicOutput(pet.VisitJSON(map[string]interface{}{"name": "Rex"})))
+
+	err = pet.VisitJSON(map[string]interface{}{
+		"name": "Snoopy",
+		"tags": []interface{}{1.0},
+	}, MultiErrors(), SchemaLocation("#/components/schemas/Pet"))
+	require.Error(t, err)
+
+	data, err := json.Marshal(NewBasicOutput(err))
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "valid": false,
+  "keywordLocation": "",
+  "instanceLocation": "",
+  "errors": [
+    {
+      "valid": false,
+      "keywordLocation": "/properties/name/$ref/maxLength",
+      "absoluteKeywordLocation": "#/components/schemas/Name/maxLength",
+      "instanceLocation": "/name",
+      "error": "maximum string length is 5"
+    },
+    {
+      "valid": false,
+      "keywordLocation": "/properties/tags/items/type",
+      "absoluteKeywordLocation": "#/components/schemas/Pet/properties/tags/items/type",
+      "instanceLocation": "/tags/0",
+      "error": "Field must be set to string or not be present"
+    }
+  ]
+}`, sortedOutput(t, data))
+}
+
+func TestSchemaErrorDetailedOutput(t *testing.T) {
+	schema := &Schema{
+		AllOf: SchemaRefs{
+			NewSchemaRef("#/components/schemas/Named", NewObjectSchema().WithProperty("name", NewStringSchema())),
+		},
+	}
+	err := schema.VisitJSON(map[string]interface{}{"name": 42.0})
+	require.Error(t, err)
+
+	require.Equal(t, &SchemaErrorOutput{
+		Errors: []*SchemaErrorOutput{{
+			KeywordLocation:  "/allOf",
+			InstanceLocation: "",
+			Error:            `Doesn't match schema "allOf"`,
+			Errors: []*SchemaErrorOutput{{
+				KeywordLocation:         "/allOf/0/$ref/properties/name/type",
+				AbsoluteKeywordLocation: "#/components/schemas/Named/properties/name/type",
+				InstanceLocation:        "/name",
+				Error:                   "Field must be set to string or not be present",
+			}},
+		}},
+	}, NewDetailedOutput(err))
+}
+
+// sortedOutput orders basic output units by instance location as
+// errors from object properties are found in map iteration order.
+func sortedOutput(t *testing.T, data []byte) string {
+	var output SchemaErrorOutput
+	require.NoError(t, json.Unmarshal(data, &output))
+	sort.Slice(output.Errors, func(i, j int) bool {
+		return output.Errors[i].InstanceLocation < output.Errors[j].InstanceLocation
+	})
+	data, err := json.Marshal(output)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,101 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var readOnlyWriteOnlySpec = []byte(`
+openapi: 3.0.0
+info:
+  title: Accounts
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Identified:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+          readOnly: true
+    Account:
+      allOf:
+        - $ref: "#/components/schemas/Identified"
+        - type: object
+          required: [name, password]
+          properties:
+            name:
+              type: string
+            password:
+              type: string
+              writeOnly: true
+    Accounts:
+      type: array
+      items:
+        $ref: "#/components/schemas/Account"
+    AccountsByName:
+      type: object
+      additionalProperties:
+        $ref: "#/components/schemas/Account"
+    Overlay:
+      allOf:
+        - $ref: "#/components/schemas/Account"
+        - required: [id, password]
+`)
+
+func TestVisitJSON_ReadOnlyWriteOnly(t *testing.T) {
+	doc, err := NewLoader().LoadFromData(readOnlyWriteOnlySpec)
+	require.NoError(t, err)
+	schemas := doc.Components.Schemas
+
+	request := map[string]interface{}{"name": "Rex", "password": "secret"}
+	response := map[string]interface{}{"id": "1", "name": "Rex"}
+
+	for _, name := range []string{"Account", "Overlay"} {
+		t.Run(name, func(t *testing.T) {
+			schema := schemas[name].Value
+			require.NoError(t, schema.VisitJSON(request, VisitAsRequest()))
+			require.Error(t, schema.VisitJSON(request, VisitAsResponse()))
+			require.NoError(t, schema.VisitJSON(response, VisitAsResponse()))
+			require.Error(t, schema.VisitJSON(response, VisitAsRequest()))
+		})
+	}
+
+	t.Run("items", func(t *testing.T) {
+		schema := schemas["Accounts"].Value
+		require.NoError(t, schema.VisitJSON([]interface{}{request}, VisitAsRequest()))
+		require.NoError(t, schema.VisitJSON([]interface{}{response}, VisitAsResponse()))
+	})
+
+	t.Run("additionalProperties", func(t *testing.T) {
+		schema := schemas["AccountsByName"].Value
+		require.NoError(t, schema.VisitJSON(map[string]interface{}{"rex": request}, VisitAsRequest()))
+		require.NoError(t, schema.VisitJSON(map[string]interface{}{"rex": response}, VisitAsResponse()))
+	})
+
+	t.Run("writeOnly in response", func(t *testing.T) {
+		err := schemas["Accounts"].Value.VisitJSON([]interface{}{
+			map[string]interface{}{"id": "1", "name": "Rex", "password": "secret"},
+		}, VisitAsResponse())
+		require.Error(t, err)
+		schemaErr, ok := err.(*SchemaError)
+		require.True(t, ok)
+		require.Equal(t, "allOf", schemaErr.SchemaField)
+		schemaErr, ok = schemaErr.Origin.(*SchemaError)
+		require.True(t, ok)
+		require.Equal(t, "/0/password", schemaErr.InstanceLocation())
+		require.Equal(t, `writeOnly property "password" in response`, schemaErr.Reason)
+	})
+
+	t.Run("readOnly in request", func(t *testing.T) {
+		value := map[string]interface{}{"id": "1", "name": "Rex", "password": "secret"}
+		schema := schemas["Account"].Value
+		require.NoError(t, schema.VisitJSON(value, VisitAsRequest()))
+		err := schema.VisitJSON(value, VisitAsRequest(), RejectReadOnly())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), `readOnly property "id" in request`)
+	})
+}
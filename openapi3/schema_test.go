@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"reflect"
 	"strings"
@@ -1242,3 +1243,24 @@ func TestValidationFailsOnInvalidPattern(t *testing.T) {
 	var err = schema.Validate(context.Background())
 	require.Error(t, err)
 }
+
+func TestVisitJSONBinary(t *testing.T) {
+	schema := NewStringSchema().WithFormat("binary").WithMinLength(2).WithMaxLength(4)
+
+	require.NoError(t, schema.VisitJSON(strings.NewReader("abc")))
+	// Lengths are in bytes
+	require.NoError(t, schema.VisitJSON(strings.NewReader("é")))
+	// Readers without a size have their content unchecked
+	require.NoError(t, schema.VisitJSON(struct{ io.Reader }{strings.NewReader("abcdef")}))
+
+	err := schema.VisitJSON(strings.NewReader("a"))
+	require.Error(t, err)
+	require.Equal(t, "minLength", err.(*SchemaError).SchemaField)
+	err = schema.VisitJSON(strings.NewReader("abcdef"))
+	require.Error(t, err)
+	require.Equal(t, "maxLength", err.(*SchemaError).SchemaField)
+
+	err = NewIntegerSchema().VisitJSON(strings.NewReader("1"))
+	require.Error(t, err)
+	require.Equal(t, "type", err.(*SchemaError).SchemaField)
+}
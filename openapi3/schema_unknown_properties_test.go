@@ -0,0 +1,133 @@
+package openapi3
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var unknownPropertiesSpec = []byte(`
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Named:
+      type: object
+      additionalProperties: false
+      properties:
+        name:
+          type: string
+    Pet:
+      allOf:
+        - $ref: "#/components/schemas/Named"
+        - type: object
+          properties:
+            age:
+              type: integer
+            owner:
+              type: object
+              properties:
+                email:
+                  type: string
+    Animal:
+      type: object
+      properties:
+        kind:
+          type: string
+      oneOf:
+        - type: object
+          properties:
+            barks:
+              type: boolean
+          required: [barks]
+        - type: object
+          properties:
+            meows:
+              type: boolean
+          required: [meows]
+    Exclusive:
+      oneOf:
+        - type: object
+          required: [a]
+          properties:
+            a:
+              type: integer
+          additionalProperties: false
+        - type: object
+          properties:
+            b:
+              type: integer
+          additionalProperties: false
+    Labels:
+      type: object
+      properties:
+        main:
+          type: string
+      additionalProperties:
+        type: string
+    Anything:
+      type: object
+`)
+
+func TestVisitJSON_RejectUnknownProperties(t *testing.T) {
+	doc, err := NewLoader().LoadFromData(unknownPropertiesSpec)
+	require.NoError(t, err)
+	schemas := doc.Components.Schemas
+
+	t.Run("allOf", func(t *testing.T) {
+		pet := schemas["Pet"].Value
+		value := map[string]interface{}{"name": "Rex", "age": 3.0, "owner": map[string]interface{}{"email": "a@b.c"}}
+		require.Error(t, pet.VisitJSON(value), "additionalProperties: false rejects sibling properties by default")
+		require.NoError(t, pet.VisitJSON(value, RejectUnknownProperties()))
+
+		err := pet.VisitJSON(map[string]interface{}{"name": "Rex", "agee": 3.0}, RejectUnknownProperties())
+		require.Error(t, err)
+		schemaErr, ok := err.(*SchemaError)
+		require.True(t, ok)
+		require.Equal(t, "additionalProperties", schemaErr.SchemaField)
+		require.Equal(t, "/agee", schemaErr.InstanceLocation())
+
+		err = pet.VisitJSON(map[string]interface{}{"name": "Rex", "owner": map[string]interface{}{"emial": "a@b.c"}}, RejectUnknownProperties())
+		require.Error(t, err)
+		output := NewBasicOutput(err)
+		unit := output.Errors[len(output.Errors)-1]
+		require.Equal(t, "/owner/emial", unit.InstanceLocation)
+		require.Equal(t, "/allOf/1/properties/owner/additionalProperties", unit.KeywordLocation)
+	})
+
+	t.Run("oneOf", func(t *testing.T) {
+		animal := schemas["Animal"].Value
+		require.NoError(t, animal.VisitJSON(map[string]interface{}{"kind": "dog", "barks": true}, RejectUnknownProperties()))
+		require.NoError(t, animal.VisitJSON(map[string]interface{}{"kind": "cat", "meows": true}, RejectUnknownProperties()))
+		require.NoError(t, animal.VisitJSON(map[string]interface{}{"kind": "cat", "meows": true, "color": "black"}))
+		err := animal.VisitJSON(map[string]interface{}{"kind": "cat", "meows": true, "color": "black"}, RejectUnknownProperties())
+		require.Error(t, err)
+		schemaErr, ok := err.(*SchemaError)
+		require.True(t, ok)
+		require.Equal(t, "/color", schemaErr.InstanceLocation())
+	})
+
+	t.Run("exclusive oneOf", func(t *testing.T) {
+		exclusive := schemas["Exclusive"].Value
+		value := map[string]interface{}{"a": 1.0}
+		require.NoError(t, exclusive.VisitJSON(value))
+		require.NoError(t, exclusive.VisitJSON(value, RejectUnknownProperties()),
+			"additionalProperties: false still tells oneOf subschemas apart")
+		require.NoError(t, exclusive.VisitJSON(map[string]interface{}{"b": 1.0}, RejectUnknownProperties()))
+		require.Error(t, exclusive.VisitJSON(map[string]interface{}{"a": 1.0, "b": 1.0}, RejectUnknownProperties()))
+	})
+
+	t.Run("additionalProperties", func(t *testing.T) {
+		labels := schemas["Labels"].Value
+		require.NoError(t, labels.VisitJSON(map[string]interface{}{"main": "a", "other": "b"}, RejectUnknownProperties()))
+		require.Error(t, labels.VisitJSON(map[string]interface{}{"main": "a", "other": 1.0}, RejectUnknownProperties()))
+	})
+
+	t.Run("free-form", func(t *testing.T) {
+		anything := schemas["Anything"].Value
+		require.NoError(t, anything.VisitJSON(map[string]interface{}{"any": "thing"}, RejectUnknownProperties()))
+	})
+}
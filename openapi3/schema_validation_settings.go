@@ -7,6 +7,19 @@ type schemaValidationSettings struct {
 	failfast     bool
 	multiError   bool
 	asreq, asrep bool // exclusive (XOR) fields
+	location     string
+
+	rejectReadOnly bool
+	coerce         bool
+	rejectUnknown  bool
+	// enclosingProperties holds the properties declared next to an "allOf",
+	// "oneOf" or "anyOf" and by its subschemas, so each subschema knows about
+	// "readOnly" and "writeOnly" properties it requires but does not declare,
+	// and about properties it does not declare but are not unknown.
+	enclosingProperties Schemas
+	// inAllOf is set while validating an "allOf" subschema, whose
+	// "additionalProperties: false" RejectUnknownProperties supersedes.
+	inAllOf bool
 }
 
 // FailFast returns schema validation errors quicker.
@@ -18,13 +31,53 @@ func MultiErrors() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.multiError = true }
 }
 
+// VisitAsRequest validates values as sent in requests: required "readOnly"
+// properties may be absent.
 func VisitAsRequest() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.asreq, s.asrep = true, false }
 }
+
+// VisitAsResponse validates values as sent in responses: required "writeOnly"
+// properties may be absent and present "writeOnly" properties are rejected.
 func VisitAsResponse() SchemaValidationOption {
 	return func(s *schemaValidationSettings) { s.asreq, s.asrep = false, true }
 }
 
+// RejectReadOnly makes VisitAsRequest reject "readOnly" properties
+// instead of ignoring them.
+func RejectReadOnly() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.rejectReadOnly = true }
+}
+
+// SchemaLocation sets where the validated schema lives in the document
+// (e.g. "#/components/schemas/Pet") so errors report absolute keyword locations
+// even when no "$ref" is followed during validation.
+func SchemaLocation(location string) SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.location = location }
+}
+
+// RejectUnknownProperties rejects object properties that are not declared
+// by the schema nor by its "allOf", "oneOf" and "anyOf" subschemas, unless
+// one of these allows additional properties. Objects whose schemas declare no
+// properties at all are not checked. "additionalProperties: false" in
+// subschemas of an "allOf" is superseded by this check on the schema holding
+// them, so siblings may declare other properties. "oneOf" and "anyOf"
+// subschemas keep their own "additionalProperties", which may be what
+// tells them apart.
+func RejectUnknownProperties() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.rejectUnknown = true }
+}
+
+// CoerceTypes converts values to the type declared by their schema before
+// validating them: strings to numbers, integers and booleans, single values
+// to one-element arrays and null to the schema's default.
+// Values are not converted under "oneOf", "anyOf" and "not".
+// Objects and arrays are converted in place, see Schema.CoerceJSON
+// to get the converted value back.
+func CoerceTypes() SchemaValidationOption {
+	return func(s *schemaValidationSettings) { s.coerce = true }
+}
+
 func newSchemaValidationSettings(opts ...SchemaValidationOption) *schemaValidationSettings {
 	settings := &schemaValidationSettings{}
 	for _, opt := range opts {
@@ -32,3 +85,53 @@ func newSchemaValidationSettings(opts ...SchemaValidationOption) *schemaValidati
 	}
 	return settings
 }
+
+// withEnclosingProperties returns settings for validating
+// the "allOf", "oneOf", "anyOf" and "not" subschemas of schema.
+func (settings *schemaValidationSettings) withEnclosingProperties(schema *Schema) *schemaValidationSettings {
+	properties := make(Schemas, len(settings.enclosingProperties))
+	for k, v := range settings.enclosingProperties {
+		properties[k] = v
+	}
+	for k, v := range schema.declaredProperties().properties {
+		properties[k] = v
+	}
+	s := *settings
+	s.enclosingProperties = properties
+	s.inAllOf = false
+	return &s
+}
+
+// forAllOf returns settings for validating the "allOf" subschemas of a schema.
+func (settings *schemaValidationSettings) forAllOf() *schemaValidationSettings {
+	if !settings.rejectUnknown || settings.inAllOf {
+		return settings
+	}
+	s := *settings
+	s.inAllOf = true
+	return &s
+}
+
+// forAlternatives returns settings for validating against the alternative
+// "oneOf", "anyOf" and "not" subschemas: values are not converted and
+// their own "additionalProperties" apply.
+func (settings *schemaValidationSettings) forAlternatives() *schemaValidationSettings {
+	if !settings.coerce && !settings.inAllOf {
+		return settings
+	}
+	s := *settings
+	s.coerce = false
+	s.inAllOf = false
+	return &s
+}
+
+// forSubschemas returns settings for validating properties and items.
+func (settings *schemaValidationSettings) forSubschemas() *schemaValidationSettings {
+	if settings.enclosingProperties == nil && !settings.inAllOf {
+		return settings
+	}
+	s := *settings
+	s.enclosingProperties = nil
+	s.inAllOf = false
+	return &s
+}
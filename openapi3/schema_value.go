@@ -0,0 +1,267 @@
+package openapi3
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/jsoninfo"
+)
+
+// VisitValue validates a Go value the way VisitJSON validates its JSON encoding,
+// without encoding it: the value is read through reflection into the values VisitJSON
+// takes, structs through their json tags, typed slices and maps as arrays and objects,
+// numbers of any kind as numbers, time.Time as a date-time string and nil pointers,
+// slices and maps as null. Integers that a float64 cannot hold exactly are compared exactly.
+// Errors have the same locations as those returned by VisitJSON.
+func (schema *Schema) VisitValue(value interface{}, opts ...SchemaValidationOption) error {
+	v, err := newJSONValueConverter().valueOf(reflect.ValueOf(value))
+	if err != nil {
+		return err
+	}
+	return schema.VisitJSON(v, opts...)
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// maxExactFloatInt is the largest integer below which all integers are held exactly by a float64.
+const maxExactFloatInt = 1 << 53
+
+// jsonValueConverter converts Go values to the values encoding/json would decode from their encodings.
+type jsonValueConverter struct {
+	// seen holds the pointers, maps and slices being converted, to detect cycles.
+	seen map[valueIdentity]struct{}
+}
+
+// valueIdentity identifies the memory a pointer, map or slice refers to.
+type valueIdentity struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+func newJSONValueConverter() *jsonValueConverter {
+	return &jsonValueConverter{seen: make(map[valueIdentity]struct{})}
+}
+
+// valueOf converts a Go value to the value encoding/json would decode from its encoding.
+// As encoding/json does, it fails on values that refer to themselves.
+func (c *jsonValueConverter) valueOf(v reflect.Value) (interface{}, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+	}
+
+	if v.Type() == timeType {
+		return v.Interface().(time.Time).Format(time.RFC3339Nano), nil
+	}
+	if v.Type().Implements(jsonMarshalerType) {
+		data, err := v.Interface().(json.Marshaler).MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+	if v.Type().Implements(textMarshalerType) {
+		data, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		if p := v.Addr(); p.Type().Implements(jsonMarshalerType) || p.Type().Implements(textMarshalerType) {
+			return c.valueOf(p)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if !v.IsNil() {
+			id := valueIdentity{ptr: v.Pointer(), typ: v.Type()}
+			if v.Kind() == reflect.Slice {
+				id.len = v.Len()
+			}
+			if _, ok := c.seen[id]; ok {
+				return nil, fmt.Errorf("cycle through a value of type %s", v.Type())
+			}
+			c.seen[id] = struct{}{}
+			defer delete(c.seen, id)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return c.valueOf(v.Elem())
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		// Integers that a float64 cannot hold exactly are kept as they are to be compared exactly
+		i := v.Int()
+		if -maxExactFloatInt <= i && i <= maxExactFloatInt {
+			return float64(i), nil
+		}
+		return i, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i := v.Uint()
+		if i <= maxExactFloatInt {
+			return float64(i), nil
+		}
+		return i, nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return base64.StdEncoding.EncodeToString(v.Bytes()), nil
+		}
+		return c.arrayOf(v)
+	case reflect.Array:
+		return c.arrayOf(v)
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return c.objectOfMap(v)
+	case reflect.Struct:
+		return c.objectOfStruct(v)
+	}
+	return nil, fmt.Errorf("unsupported value of type %s", v.Type())
+}
+
+func (c *jsonValueConverter) arrayOf(v reflect.Value) (interface{}, error) {
+	values := make([]interface{}, v.Len())
+	for i := range values {
+		value, err := c.valueOf(v.Index(i))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (c *jsonValueConverter) objectOfMap(v reflect.Value) (interface{}, error) {
+	values := make(map[string]interface{}, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := jsonKeyOf(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		value, err := c.valueOf(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func jsonKeyOf(k reflect.Value) (string, error) {
+	if k.Kind() == reflect.String {
+		return k.String(), nil
+	}
+	if m, ok := k.Interface().(encoding.TextMarshaler); ok {
+		data, err := m.MarshalText()
+		return string(data), err
+	}
+	switch k.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(k.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(k.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("unsupported map key of type %s", k.Type())
+}
+
+func (c *jsonValueConverter) objectOfStruct(v reflect.Value) (interface{}, error) {
+	typeInfo := jsoninfo.GetTypeInfo(v.Type())
+	values := make(map[string]interface{}, len(typeInfo.Fields))
+	for _, field := range typeInfo.Fields {
+		fv, ok := fieldByIndex(v, field.Index)
+		if !ok || (field.JSONOmitEmpty && isEmptyValue(fv)) {
+			continue
+		}
+		value, err := c.valueOf(fv)
+		if err != nil {
+			return nil, err
+		}
+		if field.JSONString {
+			value = stringOptionValue(value)
+		}
+		values[field.JSONName] = value
+	}
+	return values, nil
+}
+
+// fieldByIndex returns a field of a struct, or false if it is reached through a nil embedded pointer.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// stringOptionValue encodes scalars as strings, as the json tag ",string" option does.
+func stringOptionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case string:
+		return strconv.Quote(v)
+	}
+	return value
+}
+
+// isEmptyValue tells whether a value is omitted by the json tag ",omitempty" option.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
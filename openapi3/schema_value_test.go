@@ -0,0 +1,161 @@
+package openapi3
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type valueItem struct {
+	Name  string  `json:"name"`
+	Price float32 `json:"price"`
+	Note  *string `json:"note"`
+}
+
+type valueAudit struct {
+	Created time.Time `json:"created"`
+}
+
+type valueOrder struct {
+	valueAudit
+	ID       uint64          `json:"id,string"`
+	Items    []valueItem     `json:"items"`
+	Tags     map[string]int8 `json:"tags,omitempty"`
+	Coupon   string          `json:"coupon,omitempty"`
+	Raw      json.RawMessage `json:"raw,omitempty"`
+	Counts   map[int]string  `json:"counts,omitempty"`
+	internal string
+}
+
+func valueOrderSchema() *Schema {
+	item := NewObjectSchema().
+		WithProperty("name", NewStringSchema().WithMinLength(1)).
+		WithProperty("price", NewFloat64Schema().WithMin(0)).
+		WithProperty("note", &Schema{Type: TypeString, Nullable: true})
+	item.Required = []string{"name", "price", "note"}
+	order := NewObjectSchema().
+		WithProperty("created", NewDateTimeSchema()).
+		WithProperty("id", NewStringSchema().WithPattern(`^\d+$`)).
+		WithPropertyRef("items", &SchemaRef{Value: NewArraySchema().WithItems(item)}).
+		WithProperty("tags", NewObjectSchema().WithAdditionalProperties(NewIntegerSchema().WithMax(10))).
+		WithProperty("raw", NewObjectSchema()).
+		WithProperty("counts", NewObjectSchema().WithAdditionalProperties(NewStringSchema()))
+	order.Required = []string{"created", "id", "items"}
+	order.AdditionalPropertiesAllowed = BoolPtr(false)
+	return order
+}
+
+func TestVisitValue(t *testing.T) {
+	schema := valueOrderSchema()
+	note := "fragile"
+	order := &valueOrder{
+		valueAudit: valueAudit{Created: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+		ID:         42,
+		Items: []valueItem{
+			{Name: "box", Price: 1.5, Note: &note},
+			{Name: "bag", Price: 0},
+		},
+		Tags:   map[string]int8{"a": 1},
+		Raw:    json.RawMessage(`{"x": 1}`),
+		Counts: map[int]string{1: "one"},
+	}
+	require.NoError(t, schema.VisitValue(order))
+
+	order.Items[1].Name = ""
+	err := schema.VisitValue(order)
+	require.Error(t, err)
+	schemaErr, ok := err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/items/1/name", schemaErr.InstanceLocation())
+	require.Equal(t, "minLength", schemaErr.SchemaField)
+
+	order.Items[1].Name = "bag"
+	order.Tags["b"] = 11
+	err = schema.VisitValue(order)
+	require.Error(t, err)
+	schemaErr, ok = err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/tags/b", schemaErr.InstanceLocation())
+
+	// The same errors as for the JSON encoding of the value
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+	var value interface{}
+	require.NoError(t, json.Unmarshal(data, &value))
+	require.Equal(t, schema.VisitJSON(value).Error(), schema.VisitValue(order).Error())
+}
+
+func TestVisitValueNull(t *testing.T) {
+	schema := NewObjectSchema().WithProperty("items", NewArraySchema().WithItems(NewIntegerSchema()))
+	require.NoError(t, schema.VisitValue(map[string][]uint{"items": {1, 2}}))
+
+	err := schema.VisitValue(map[string][]uint{"items": nil})
+	require.Error(t, err)
+	schemaErr, ok := err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/items", schemaErr.InstanceLocation())
+	require.Equal(t, "nullable", schemaErr.SchemaField)
+
+	var order *valueOrder
+	err = valueOrderSchema().VisitValue(order)
+	require.Error(t, err)
+	schemaErr, ok = err.(*SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "nullable", schemaErr.SchemaField)
+	require.NoError(t, NewObjectSchema().WithNullable().VisitValue(order))
+}
+
+type valueNode struct {
+	Name string     `json:"name"`
+	Next *valueNode `json:"next,omitempty"`
+}
+
+func TestVisitValueCycle(t *testing.T) {
+	schema := NewObjectSchema()
+
+	// Shared values are not cycles
+	leaf := &valueNode{Name: "leaf"}
+	require.NoError(t, NewArraySchema().VisitValue([]*valueNode{leaf, leaf}))
+
+	node := &valueNode{Name: "a"}
+	node.Next = &valueNode{Name: "b", Next: node}
+	err := schema.VisitValue(node)
+	require.EqualError(t, err, "cycle through a value of type *openapi3.valueNode")
+
+	loop := map[string]interface{}{}
+	loop["self"] = loop
+	require.Error(t, schema.VisitValue(loop))
+}
+
+func TestVisitValueLargeIntegers(t *testing.T) {
+	const above = int64(1<<53 + 1) // a float64 rounds it to 1<<53
+	for _, tc := range []struct {
+		schema *Schema
+		value  interface{}
+		field  string
+	}{
+		{schema: NewInt64Schema().WithMax(1 << 53), value: above, field: "maximum"},
+		{schema: NewInt64Schema().WithMax(1<<53 + 2), value: above},
+		{schema: NewInt64Schema().WithMin(-1 << 53), value: -above, field: "minimum"},
+		{schema: &Schema{Type: TypeInteger, MultipleOf: Float64Ptr(2)}, value: above, field: "multipleOf"},
+		{schema: &Schema{Type: TypeInteger, MultipleOf: Float64Ptr(3)}, value: above},
+		{schema: NewInt64Schema().WithEnum(float64(1 << 53)), value: above, field: "enum"},
+		{schema: NewInt64Schema().WithEnum(float64(1<<53 + 2)), value: above + 1},
+		{schema: NewInt64Schema().WithMin(1 << 64), value: uint64(1<<64 - 1), field: "minimum"},
+		{schema: NewObjectSchema().WithProperty("id", NewStringSchema().WithPattern(`^9007199254740993$`)), value: struct {
+			ID int64 `json:"id,string"`
+		}{above}},
+	} {
+		err := tc.schema.VisitValue(tc.value)
+		if tc.field == "" {
+			require.NoError(t, err)
+			continue
+		}
+		var schemaErr *SchemaError
+		require.True(t, errors.As(err, &schemaErr), "%v", err)
+		require.Equal(t, tc.field, schemaErr.SchemaField)
+	}
+}
@@ -109,7 +109,8 @@ func (ss *SecurityScheme) WithBearerFormat(value string) *SecurityScheme {
 }
 
 // Validate returns an error if SecurityScheme does not comply with the OpenAPI spec.
-func (ss *SecurityScheme) Validate(ctx context.Context) error {
+func (ss *SecurityScheme) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(ss.Origin, err) }()
 	hasIn := false
 	hasBearerFormat := false
 	hasFlow := false
@@ -164,7 +165,7 @@ func (ss *SecurityScheme) Validate(ctx context.Context) error {
 			return fmt.Errorf("security scheme of type %q should have 'flows'", ss.Type)
 		}
 		if err := flow.Validate(ctx); err != nil {
-			return fmt.Errorf("security scheme 'flow' is invalid: %v", err)
+			return fmt.Errorf("security scheme 'flow' is invalid: %w", err)
 		}
 	} else if ss.Flows != nil {
 		return fmt.Errorf("security scheme of type %q can't have 'flows'", ss.Type)
@@ -203,7 +204,8 @@ func (flows *OAuthFlows) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if OAuthFlows does not comply with the OpenAPI spec.
-func (flows *OAuthFlows) Validate(ctx context.Context) error {
+func (flows *OAuthFlows) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(flows.Origin, err) }()
 	if v := flows.Implicit; v != nil {
 		return v.Validate(ctx, oAuthFlowTypeImplicit)
 	}
@@ -241,7 +243,8 @@ func (flow *OAuthFlow) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if OAuthFlow does not comply with the OpenAPI spec.
-func (flow *OAuthFlow) Validate(ctx context.Context, typ oAuthFlowType) error {
+func (flow *OAuthFlow) Validate(ctx context.Context, typ oAuthFlowType) (err error) {
+	defer func() { err = withOrigin(flow.Origin, err) }()
 	if typ == oAuthFlowAuthorizationCode || typ == oAuthFlowTypeImplicit {
 		if v := flow.AuthorizationURL; v == "" {
 			return errors.New("an OAuth flow is missing 'authorizationUrl in authorizationCode or implicit '")
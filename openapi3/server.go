@@ -131,6 +131,7 @@ func (server Server) MatchRawURL(input string) ([]string, string, bool) {
 
 // Validate returns an error if Server does not comply with the OpenAPI spec.
 func (server *Server) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(server.Origin, err) }()
 	if server.URL == "" {
 		return errors.New("value of url must be a non-empty string")
 	}
@@ -173,7 +174,8 @@ func (serverVariable *ServerVariable) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if ServerVariable does not comply with the OpenAPI spec.
-func (serverVariable *ServerVariable) Validate(ctx context.Context) error {
+func (serverVariable *ServerVariable) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(serverVariable.Origin, err) }()
 	if serverVariable.Default == "" {
 		data, err := serverVariable.MarshalJSON()
 		if err != nil {
@@ -50,7 +50,8 @@ func (t *Tag) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if Tag does not comply with the OpenAPI spec.
-func (t *Tag) Validate(ctx context.Context) error {
+func (t *Tag) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(t.Origin, err) }()
 	if v := t.ExternalDocs; v != nil {
 		if err := v.Validate(ctx); err != nil {
 			return fmt.Errorf("invalid external docs: %w", err)
@@ -29,6 +29,7 @@ func (xml *XML) UnmarshalJSON(data []byte) error {
 }
 
 // Validate returns an error if XML does not comply with the OpenAPI spec.
-func (xml *XML) Validate(ctx context.Context) error {
+func (xml *XML) Validate(ctx context.Context) (err error) {
+	defer func() { err = withOrigin(xml.Origin, err) }()
 	return nil // TODO
 }
@@ -0,0 +1,478 @@
+// Package openapi3codegen generates Go types from OpenAPIv3 schemas,
+// as the inverse of package openapi3gen.
+package openapi3codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+// Option allows tweaking code generation
+type Option func(*generatorOpt)
+
+type generatorOpt struct {
+	packageName string
+}
+
+// PackageName sets the name of the package of the generated source. The default is "api".
+func PackageName(name string) Option {
+	return func(x *generatorOpt) { x.packageName = name }
+}
+
+// GenerateTypes returns the Go source declaring a type for each schema in the components of doc.
+//
+// Refs to #/components/schemas are generated as references to the type of the component:
+// external refs should be internalized first with T.InternalizeRefs.
+// Objects are generated as structs with json tags, optional and nullable
+// properties being pointers. Enums are generated as typed constants,
+// oneOf with a discriminator as a sealed interface with a wrapper
+// decoding its implementations, and additionalProperties as maps.
+func GenerateTypes(doc *openapi3.T, opts ...Option) ([]byte, error) {
+	g := &generator{
+		opts:      generatorOpt{packageName: "api"},
+		schemas:   doc.Components.Schemas,
+		typeNames: make(map[string]string),
+		declared:  make(map[string]string),
+		sealed:    make(map[string]*sealedInterface),
+		imports:   make(map[string]struct{}),
+	}
+	for _, f := range opts {
+		f(&g.opts)
+	}
+
+	names := make([]string, 0, len(g.schemas))
+	for name := range g.schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		typeName := goName(name)
+		if other, ok := g.declared[typeName]; ok {
+			return nil, fmt.Errorf("components %q and %q are both generated as type %s", other, name, typeName)
+		}
+		g.declared[typeName] = name
+		g.typeNames[name] = typeName
+	}
+	for _, name := range names {
+		if err := g.findSealedInterface(g.typeNames[name], g.schemas[name]); err != nil {
+			return nil, fmt.Errorf("component %q: %w", name, err)
+		}
+	}
+	for _, name := range names {
+		if err := g.declare(g.typeNames[name], g.schemas[name]); err != nil {
+			return nil, fmt.Errorf("component %q: %w", name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by openapi3codegen. DO NOT EDIT.\n\npackage %s\n\n", g.opts.packageName)
+	if len(g.imports) != 0 {
+		imports := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			imports = append(imports, strconv.Quote(path))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&buf, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	for _, decl := range g.decls {
+		buf.WriteString(decl)
+		buf.WriteString("\n")
+	}
+	return format.Source(buf.Bytes())
+}
+
+type generator struct {
+	opts    generatorOpt
+	schemas openapi3.Schemas
+
+	// typeNames maps component names to the name of their type
+	typeNames map[string]string
+	// declared maps the names of the declared types to what they were generated from
+	declared map[string]string
+	// sealed maps the names of the sealed interfaces to their implementations
+	sealed map[string]*sealedInterface
+
+	decls   []string
+	imports map[string]struct{}
+}
+
+// sealedInterface is generated for a oneOf with a discriminator.
+type sealedInterface struct {
+	propertyName string
+	values       []string
+	types        []string
+}
+
+// findSealedInterface records the components generated as sealed interfaces,
+// before any type is declared, so that references to them can use their wrapper.
+func (g *generator) findSealedInterface(typeName string, ref *openapi3.SchemaRef) error {
+	schema := ref.Value
+	if schema == nil || schema.Discriminator == nil || len(schema.OneOf) == 0 {
+		return nil
+	}
+	sealed := &sealedInterface{propertyName: schema.Discriminator.PropertyName}
+	mapped := make(map[string]string, len(schema.Discriminator.Mapping))
+	for value, ref := range schema.Discriminator.Mapping {
+		mapped[ref] = value
+	}
+	for _, item := range schema.OneOf {
+		if !strings.HasPrefix(item.Ref, componentsPrefix) {
+			return fmt.Errorf("oneOf with a discriminator must reference components, not %q", item.Ref)
+		}
+		component := strings.TrimPrefix(item.Ref, componentsPrefix)
+		implName, ok := g.typeNames[component]
+		if !ok {
+			return fmt.Errorf("unknown component %q", component)
+		}
+		value, ok := mapped[item.Ref]
+		if !ok {
+			// Without a mapping, the discriminator value is the name of the component
+			value = component
+		}
+		sealed.values = append(sealed.values, value)
+		sealed.types = append(sealed.types, implName)
+	}
+	g.sealed[typeName] = sealed
+	return nil
+}
+
+// declare generates the declaration of a named type.
+func (g *generator) declare(typeName string, ref *openapi3.SchemaRef) error {
+	schema := ref.Value
+	if schema == nil {
+		return fmt.Errorf("unresolved ref %q", ref.Ref)
+	}
+
+	var buf strings.Builder
+	writeComment(&buf, schema.Description)
+
+	switch {
+	case strings.HasPrefix(ref.Ref, componentsPrefix) && g.typeNames[strings.TrimPrefix(ref.Ref, componentsPrefix)] != typeName:
+		// A component that is a reference to another one
+		typ, err := g.typeExpr(ref, typeName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "type %s = %s\n", typeName, typ)
+
+	case g.sealed[typeName] != nil:
+		g.declareSealedInterface(&buf, typeName, g.sealed[typeName])
+
+	case len(schema.Enum) != 0:
+		if err := g.declareEnum(&buf, typeName, schema); err != nil {
+			return err
+		}
+
+	case isStruct(schema):
+		if err := g.declareStruct(&buf, typeName, schema); err != nil {
+			return err
+		}
+
+	default:
+		typ, err := g.typeExpr(&openapi3.SchemaRef{Value: schema}, typeName)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&buf, "type %s %s\n", typeName, typ)
+	}
+
+	g.decls = append(g.decls, buf.String())
+	return nil
+}
+
+// declareNested declares a type for a schema nested in a component.
+func (g *generator) declareNested(typeName string, schema *openapi3.Schema) error {
+	if other, ok := g.declared[typeName]; ok {
+		return fmt.Errorf("type %s is generated for both %s and a nested schema", typeName, other)
+	}
+	g.declared[typeName] = typeName
+	if err := g.findSealedInterface(typeName, &openapi3.SchemaRef{Value: schema}); err != nil {
+		return err
+	}
+	return g.declare(typeName, &openapi3.SchemaRef{Value: schema})
+}
+
+func (g *generator) declareStruct(buf *strings.Builder, typeName string, schema *openapi3.Schema) error {
+	// Properties of inline allOf subschemas are merged, referenced ones are embedded
+	var embedded []string
+	properties := make(openapi3.Schemas)
+	required := make(map[string]bool)
+	var merge func(schema *openapi3.Schema) error
+	merge = func(schema *openapi3.Schema) error {
+		for name, property := range schema.Properties {
+			properties[name] = property
+		}
+		for _, name := range schema.Required {
+			required[name] = true
+		}
+		for _, item := range schema.AllOf {
+			if item.Ref != "" {
+				typ, err := g.typeExpr(item, "")
+				if err != nil {
+					return err
+				}
+				embedded = append(embedded, typ)
+				continue
+			}
+			if item.Value != nil {
+				if err := merge(item.Value); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := merge(schema); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(buf, "type %s struct {\n", typeName)
+	for _, typ := range embedded {
+		fmt.Fprintf(buf, "\t%s\n", typ)
+	}
+	fields := make(map[string]string, len(names))
+	for _, name := range names {
+		property := properties[name]
+		fieldName := goName(name)
+		if other, ok := fields[fieldName]; ok {
+			return fmt.Errorf("properties %q and %q are both generated as field %s", other, name, fieldName)
+		}
+		fields[fieldName] = name
+
+		typ, err := g.typeExpr(property, typeName+fieldName)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+		tag := name
+		if !required[name] {
+			tag += ",omitempty"
+		}
+		if (!required[name] || (property.Value != nil && property.Value.Nullable)) && !isReferenceType(typ) {
+			typ = "*" + typ
+		}
+		if property.Value != nil && property.Value.Description != "" {
+			writeComment(buf, property.Value.Description)
+		}
+		fmt.Fprintf(buf, "\t%s %s `json:%q`\n", fieldName, typ, tag)
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+func (g *generator) declareEnum(buf *strings.Builder, typeName string, schema *openapi3.Schema) error {
+	typ, err := g.typeExpr(&openapi3.SchemaRef{Value: &openapi3.Schema{Type: schema.Type, Format: schema.Format}}, typeName)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, "type %s %s\n\n", typeName, typ)
+
+	constants := make(map[string]bool, len(schema.Enum))
+	buf.WriteString("const (\n")
+	for _, value := range schema.Enum {
+		var literal, suffix string
+		switch v := value.(type) {
+		case string:
+			literal, suffix = strconv.Quote(v), v
+		case float64:
+			literal = strconv.FormatFloat(v, 'f', -1, 64)
+			suffix = strings.NewReplacer("-", "Minus", ".", "_").Replace(literal)
+		case bool:
+			literal = strconv.FormatBool(v)
+			suffix = literal
+		default:
+			return fmt.Errorf("unsupported enum value %v", value)
+		}
+		name := typeName + goName(suffix)
+		if suffix == "" {
+			name = typeName + "Empty"
+		}
+		if constants[name] {
+			return fmt.Errorf("enum values are both generated as constant %s", name)
+		}
+		constants[name] = true
+		fmt.Fprintf(buf, "\t%s %s = %s\n", name, typeName, literal)
+	}
+	buf.WriteString(")\n")
+	return nil
+}
+
+func (g *generator) declareSealedInterface(buf *strings.Builder, typeName string, sealed *sealedInterface) {
+	g.imports["encoding/json"] = struct{}{}
+	g.imports["fmt"] = struct{}{}
+	method := "is" + typeName
+
+	fmt.Fprintf(buf, "type %s interface {\n\t%s()\n}\n\n", typeName, method)
+	for _, implName := range sealed.types {
+		fmt.Fprintf(buf, "func (%s) %s() {}\n\n", implName, method)
+	}
+
+	valueName := typeName + "Value"
+	fmt.Fprintf(buf, "// %s holds a %s, decoded according to its %q property.\n", valueName, typeName, sealed.propertyName)
+	fmt.Fprintf(buf, "type %s struct {\n\t%s\n}\n\n", valueName, typeName)
+	fmt.Fprintf(buf, "// MarshalJSON encodes the %s held.\n", typeName)
+	fmt.Fprintf(buf, "func (v %s) MarshalJSON() ([]byte, error) {\n\treturn json.Marshal(v.%s)\n}\n\n", valueName, typeName)
+	fmt.Fprintf(buf, "// UnmarshalJSON decodes the %s implementation selected by the %q property.\n", typeName, sealed.propertyName)
+	fmt.Fprintf(buf, "func (v *%s) UnmarshalJSON(data []byte) error {\n", valueName)
+	fmt.Fprintf(buf, "\tvar discriminator struct {\n\t\tValue string `json:%q`\n\t}\n", sealed.propertyName)
+	buf.WriteString("\tif err := json.Unmarshal(data, &discriminator); err != nil {\n\t\treturn err\n\t}\n")
+	buf.WriteString("\tswitch discriminator.Value {\n")
+	for i, value := range sealed.values {
+		fmt.Fprintf(buf, "\tcase %q:\n\t\tvar value %s\n", value, sealed.types[i])
+		buf.WriteString("\t\tif err := json.Unmarshal(data, &value); err != nil {\n\t\t\treturn err\n\t\t}\n")
+		fmt.Fprintf(buf, "\t\tv.%s = value\n", typeName)
+	}
+	fmt.Fprintf(buf, "\tdefault:\n\t\treturn fmt.Errorf(\"unknown %s %%q for %s\", discriminator.Value)\n", sealed.propertyName, typeName)
+	buf.WriteString("\t}\n\treturn nil\n}\n")
+}
+
+// typeExpr returns the Go type of a schema. Nested structs, enums and sealed
+// interfaces are declared as types named after hint.
+func (g *generator) typeExpr(ref *openapi3.SchemaRef, hint string) (string, error) {
+	if strings.HasPrefix(ref.Ref, componentsPrefix) {
+		typeName, ok := g.typeNames[strings.TrimPrefix(ref.Ref, componentsPrefix)]
+		if !ok {
+			return "", fmt.Errorf("unknown component %q", ref.Ref)
+		}
+		if g.sealed[typeName] != nil {
+			return typeName + "Value", nil
+		}
+		return typeName, nil
+	}
+
+	schema := ref.Value
+	if schema == nil {
+		return "", fmt.Errorf("unresolved ref %q", ref.Ref)
+	}
+	if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		return g.typeExpr(schema.AllOf[0], hint)
+	}
+	if (schema.Discriminator != nil && len(schema.OneOf) != 0) || len(schema.Enum) != 0 || isStruct(schema) {
+		if hint == "" {
+			return "", fmt.Errorf("cannot name the type of an inline schema")
+		}
+		if err := g.declareNested(hint, schema); err != nil {
+			return "", err
+		}
+		if g.sealed[hint] != nil {
+			return hint + "Value", nil
+		}
+		return hint, nil
+	}
+
+	switch schema.Type {
+	case openapi3.TypeBoolean:
+		return "bool", nil
+	case openapi3.TypeInteger:
+		switch schema.Format {
+		case "int32":
+			return "int32", nil
+		case "int64":
+			return "int64", nil
+		}
+		return "int", nil
+	case openapi3.TypeNumber:
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case openapi3.TypeString:
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = struct{}{}
+			return "time.Time", nil
+		case "byte":
+			return "[]byte", nil
+		}
+		return "string", nil
+	case openapi3.TypeArray:
+		if schema.Items == nil {
+			return "[]interface{}", nil
+		}
+		items, err := g.typeExpr(schema.Items, hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + items, nil
+	case openapi3.TypeObject, "":
+		if additionalProperties := schema.AdditionalProperties; additionalProperties != nil {
+			elem, err := g.typeExpr(additionalProperties, hint+"Value")
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + elem, nil
+		}
+		if schema.Type == openapi3.TypeObject {
+			return "map[string]interface{}", nil
+		}
+	}
+	return "interface{}", nil
+}
+
+// isStruct tells whether a schema is generated as a struct.
+func isStruct(schema *openapi3.Schema) bool {
+	if len(schema.Properties) != 0 {
+		return true
+	}
+	if len(schema.AllOf) > 1 {
+		return true
+	}
+	return len(schema.AllOf) == 1 && schema.AllOf[0].Ref == "" && schema.AllOf[0].Value != nil && isStruct(schema.AllOf[0].Value)
+}
+
+// isReferenceType tells whether a Go type has a nil value of its own.
+func isReferenceType(typ string) bool {
+	return strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") || typ == "interface{}"
+}
+
+// initialisms are written in upper case in Go names
+var initialisms = map[string]bool{
+	"API": true, "HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true,
+	"JSON": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
+// goName turns a name into an exported Go identifier.
+func goName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var buf strings.Builder
+	for _, part := range parts {
+		if upper := strings.ToUpper(part); initialisms[upper] {
+			buf.WriteString(upper)
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		buf.WriteString(string(runes))
+	}
+	s := buf.String()
+	if s == "" || !unicode.IsLetter([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
+
+func writeComment(buf *strings.Builder, description string) {
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		fmt.Fprintf(buf, "// %s\n", strings.TrimRight(line, " "))
+	}
+}
@@ -0,0 +1,207 @@
+package openapi3codegen_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3codegen"
+	"github.com/stretchr/testify/require"
+)
+
+var petsSpec = []byte(`
+openapi: 3.0.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Base:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: integer
+          format: int64
+        created_at:
+          type: string
+          format: date-time
+    Status:
+      type: string
+      enum: [available, sold-out]
+    Cat:
+      allOf:
+        - $ref: "#/components/schemas/Base"
+        - type: object
+          required: [kind]
+          properties:
+            kind:
+              type: string
+            lives:
+              type: integer
+              nullable: true
+    Dog:
+      type: object
+      required: [kind]
+      properties:
+        kind:
+          type: string
+        tags:
+          type: array
+          items:
+            type: string
+        collar:
+          description: The collar worn by the dog.
+          type: object
+          properties:
+            color:
+              type: string
+    Pet:
+      oneOf:
+        - $ref: "#/components/schemas/Cat"
+        - $ref: "#/components/schemas/Dog"
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: "#/components/schemas/Cat"
+    Owner:
+      description: Owner owns pets.
+      type: object
+      required: [pets]
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: "#/components/schemas/Pet"
+        status:
+          $ref: "#/components/schemas/Status"
+        nicknames:
+          type: object
+          additionalProperties:
+            type: string
+        extra: {}
+`)
+
+const petsSource = "// Code generated by openapi3codegen. DO NOT EDIT.\n" + `
+package pets
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type Base struct {
+	CreatedAt *time.Time ` + "`json:\"created_at,omitempty\"`" + `
+	ID        int64      ` + "`json:\"id\"`" + `
+}
+
+type Cat struct {
+	Base
+	Kind  string ` + "`json:\"kind\"`" + `
+	Lives *int   ` + "`json:\"lives,omitempty\"`" + `
+}
+
+// The collar worn by the dog.
+type DogCollar struct {
+	Color *string ` + "`json:\"color,omitempty\"`" + `
+}
+
+type Dog struct {
+	// The collar worn by the dog.
+	Collar *DogCollar ` + "`json:\"collar,omitempty\"`" + `
+	Kind   string     ` + "`json:\"kind\"`" + `
+	Tags   []string   ` + "`json:\"tags,omitempty\"`" + `
+}
+
+// Owner owns pets.
+type Owner struct {
+	Extra     interface{}       ` + "`json:\"extra,omitempty\"`" + `
+	Nicknames map[string]string ` + "`json:\"nicknames,omitempty\"`" + `
+	Pets      []PetValue        ` + "`json:\"pets\"`" + `
+	Status    *Status           ` + "`json:\"status,omitempty\"`" + `
+}
+
+type Pet interface {
+	isPet()
+}
+
+func (Cat) isPet() {}
+
+func (Dog) isPet() {}
+
+// PetValue holds a Pet, decoded according to its "kind" property.
+type PetValue struct {
+	Pet
+}
+
+// MarshalJSON encodes the Pet held.
+func (v PetValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(v.Pet)
+}
+
+// UnmarshalJSON decodes the Pet implementation selected by the "kind" property.
+func (v *PetValue) UnmarshalJSON(data []byte) error {
+	var discriminator struct {
+		Value string ` + "`json:\"kind\"`" + `
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return err
+	}
+	switch discriminator.Value {
+	case "cat":
+		var value Cat
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		v.Pet = value
+	case "Dog":
+		var value Dog
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		v.Pet = value
+	default:
+		return fmt.Errorf("unknown kind %q for Pet", discriminator.Value)
+	}
+	return nil
+}
+
+type Status string
+
+const (
+	StatusAvailable Status = "available"
+	StatusSoldOut   Status = "sold-out"
+)
+`
+
+func TestGenerateTypes(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(petsSpec)
+	require.NoError(t, err)
+
+	source, err := openapi3codegen.GenerateTypes(doc, openapi3codegen.PackageName("pets"))
+	require.NoError(t, err)
+	require.Equal(t, petsSource, string(source))
+
+	// The generated source compiles
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "pets.go", source, 0)
+	require.NoError(t, err)
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("pets", fset, []*ast.File{file}, nil)
+	require.NoError(t, err)
+}
+
+func TestGenerateTypesNameCollision(t *testing.T) {
+	doc := &openapi3.T{Components: openapi3.Components{Schemas: openapi3.Schemas{
+		"pet_id": openapi3.NewStringSchema().NewRef(),
+		"PetID":  openapi3.NewStringSchema().NewRef(),
+	}}}
+	_, err := openapi3codegen.GenerateTypes(doc)
+	require.EqualError(t, err, `components "PetID" and "pet_id" are both generated as type PetID`)
+}
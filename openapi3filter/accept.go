@@ -0,0 +1,97 @@
+package openapi3filter
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// acceptRange is a media range of an Accept header, such as "text/*;q=0.5".
+type acceptRange struct {
+	openapi3.MediaRange
+	q float64
+}
+
+// parseAccept returns the media ranges of Accept headers. Invalid ranges are ignored.
+func parseAccept(values []string) []acceptRange {
+	var ranges []acceptRange
+	for _, value := range values {
+		for _, s := range strings.Split(value, ",") {
+			if strings.TrimSpace(s) == "" {
+				continue
+			}
+			mediaRange, ok := openapi3.ParseMediaRange(s)
+			if !ok {
+				continue
+			}
+			r := acceptRange{MediaRange: mediaRange, q: 1}
+			if q, ok := r.Params["q"]; ok {
+				v, err := strconv.ParseFloat(q, 64)
+				if err != nil || v < 0 || v > 1 {
+					continue
+				}
+				r.q = v
+				delete(r.Params, "q")
+			}
+			ranges = append(ranges, r)
+		}
+	}
+	return ranges
+}
+
+// acceptQuality returns the quality that media ranges give a media type: that of the most specific
+// range the media type is in. A media type which is a range itself, such as a key of the content
+// of a response, has the highest quality of the ranges that overlap it.
+func acceptQuality(ranges []acceptRange, value string) float64 {
+	mediaType, ok := openapi3.ParseMediaRange(value)
+	if !ok {
+		return 0
+	}
+	isRange := mediaType.Type == "*" || mediaType.Subtype == "*"
+	q, specificity := 0.0, -1
+	for _, r := range ranges {
+		if isRange {
+			if (r.Includes(mediaType) || mediaType.Includes(r.MediaRange)) && r.q > q {
+				q = r.q
+			}
+			continue
+		}
+		if r.Includes(mediaType) {
+			if s := r.Specificity(); s > specificity {
+				q, specificity = r.q, s
+			}
+		}
+	}
+	return q
+}
+
+// successContentTypes returns the media types of the content of the successful responses
+// of an operation, or of its default response if it has no successful responses.
+// The function returns false if such a response has no content, which any request accepts.
+func successContentTypes(responses openapi3.Responses) ([]string, bool) {
+	var selected []*openapi3.ResponseRef
+	for status, response := range responses {
+		if strings.HasPrefix(status, "2") {
+			selected = append(selected, response)
+		}
+	}
+	if len(selected) == 0 {
+		if response := responses.Default(); response != nil {
+			selected = append(selected, response)
+		}
+	}
+	var mediaTypes []string
+	for _, response := range selected {
+		if response == nil || response.Value == nil {
+			continue
+		}
+		if len(response.Value.Content) == 0 {
+			return nil, false
+		}
+		for mediaType := range response.Value.Content {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	return mediaTypes, true
+}
@@ -0,0 +1,127 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptQuality(t *testing.T) {
+	tests := []struct {
+		accept    string
+		mediaType string
+		want      float64
+	}{
+		{accept: "application/json", mediaType: "application/json", want: 1},
+		{accept: "application/json", mediaType: "application/json; charset=utf-8", want: 1},
+		{accept: "application/json; charset=UTF-8", mediaType: "application/json; charset=utf-8", want: 1},
+		{accept: "application/json; version=2", mediaType: "application/json", want: 0},
+		{accept: "text/html", mediaType: "application/json", want: 0},
+		{accept: "text/*;q=0.5, */*;q=0.1", mediaType: "text/plain", want: 0.5},
+		{accept: "text/*;q=0.5, */*;q=0.1", mediaType: "image/png", want: 0.1},
+		{accept: "text/plain;q=0, text/*", mediaType: "text/plain", want: 0},
+		{accept: "text/plain;q=0, text/*", mediaType: "text/csv", want: 1},
+		{accept: "image/png;q=0, image/*;q=0.8", mediaType: "image/*", want: 0.8},
+		{accept: "image/png", mediaType: "*/*", want: 1},
+		{accept: "application/json;q=2, text/plain", mediaType: "application/json", want: 0},
+		{accept: "invalid, application/json", mediaType: "application/json", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept+" "+tt.mediaType, func(t *testing.T) {
+			require.Equal(t, tt.want, acceptQuality(parseAccept([]string{tt.accept}), tt.mediaType))
+		})
+	}
+}
+
+func TestValidateAccept(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema: {type: array, items: {}}
+            text/csv:
+              schema: {type: array, items: {}}
+        default:
+          description: Error
+          content:
+            application/problem+json:
+              schema: {type: object}
+    delete:
+      responses:
+        '204':
+          description: Deleted
+`
+
+	router := setupTestRouter(t, spec)
+	options := &Options{RejectNotAcceptable: true}
+
+	newInput := func(method, accept string) *RequestValidationInput {
+		req := httptest.NewRequest(method, "/pets", nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		return &RequestValidationInput{Request: req, PathParams: pathParams, Route: route, Options: options}
+	}
+
+	require.NoError(t, ValidateRequest(context.Background(), newInput(http.MethodGet, "")))
+	require.NoError(t, ValidateRequest(context.Background(), newInput(http.MethodGet, "text/csv, application/json;q=0.5")))
+	require.NoError(t, ValidateRequest(context.Background(), newInput(http.MethodGet, "application/*")))
+	require.NoError(t, ValidateRequest(context.Background(), newInput(http.MethodDelete, "application/xml")))
+
+	input := newInput(http.MethodGet, "application/xml, application/problem+json")
+	err := ValidateRequest(context.Background(), input)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrNotAcceptable))
+
+	mockEncoder := &mockErrorEncoder{}
+	encoder := &ValidationErrorEncoder{Encoder: mockEncoder.Encode}
+	encoder.Encode(context.Background(), err, httptest.NewRecorder())
+	var validationErr *ValidationError
+	require.True(t, errors.As(mockEncoder.Err, &validationErr))
+	require.Equal(t, http.StatusNotAcceptable, validationErr.Status)
+
+	// Requests are accepted unless the option is set
+	input.Options = nil
+	require.NoError(t, ValidateRequest(context.Background(), input))
+
+	validateResponse := func(accept string, status int, contentType, body string) error {
+		input := &ResponseValidationInput{
+			RequestValidationInput: newInput(http.MethodGet, accept),
+			Status:                 status,
+			Header:                 http.Header{headerCT: {contentType}},
+			Options:                options,
+		}
+		input.SetBodyBytes([]byte(body))
+		return ValidateResponse(context.Background(), input)
+	}
+
+	require.NoError(t, validateResponse("text/csv", http.StatusOK, "text/csv; charset=utf-8", "id\n1\n"))
+	require.NoError(t, validateResponse("", http.StatusOK, "application/json", "[]"))
+
+	err = validateResponse("text/csv", http.StatusOK, "application/json", "[]")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrNotAcceptable))
+	var responseErr *ResponseError
+	require.True(t, errors.As(err, &responseErr))
+	require.Equal(t, headerCT, responseErr.Header)
+
+	// Content types that are not declared are flagged whatever the request accepts
+	err = validateResponse("*/*", http.StatusOK, "application/xml", "<pets/>")
+	require.Error(t, err)
+	require.False(t, errors.Is(err, ErrNotAcceptable))
+}
@@ -0,0 +1,249 @@
+package openapi3filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+)
+
+// readBody reads a body, failing if it is larger than maxBytes bytes when maxBytes is positive.
+func readBody(body io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
+		return ioutil.ReadAll(body)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(body, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, newBodyTooLargeError(maxBytes)
+	}
+	return data, nil
+}
+
+func newBodyTooLargeError(maxBytes int64) error {
+	return &ParseError{Kind: KindBodyTooLarge, Reason: fmt.Sprintf("body is larger than %d bytes", maxBytes)}
+}
+
+// maxNesting is the most arrays and objects, or other values nesting values, bodies nest in one another,
+// like encoding/json allows, so that decoding them does not exhaust the stack.
+const maxNesting = 10000
+
+// defaultMaxBodyBytes is the most bytes of a body that the decoders which read it whole
+// before decoding it, such as the MessagePack and CBOR decoders, read without Options.MaxBodyBytes.
+const defaultMaxBodyBytes = 32 << 20
+
+// bodyLimits are the limits of Options on a body: on its size, for the decoders that read it whole,
+// and on its value, on its nesting depth, number of values and length of strings, including object keys,
+// which body decoders enforce as they decode it. A nil *bodyLimits has no limits but the default size.
+type bodyLimits struct {
+	maxBytes        int64
+	maxDepth        int
+	maxValues       int
+	maxStringLength int
+
+	// values is the number of values of the body decoded so far.
+	values int
+	// depth is the depth of the value the value being decoded is in, such as a multipart body for its parts.
+	depth int
+}
+
+// newBodyLimits returns the limits of options on a body, or nil if it has none.
+func newBodyLimits(options *Options) *bodyLimits {
+	if options.MaxBodyBytes <= 0 && options.MaxBodyDepth <= 0 && options.MaxBodyValues <= 0 && options.MaxBodyStringLength <= 0 {
+		return nil
+	}
+	return &bodyLimits{
+		maxBytes:        options.MaxBodyBytes,
+		maxDepth:        options.MaxBodyDepth,
+		maxValues:       options.MaxBodyValues,
+		maxStringLength: options.MaxBodyStringLength,
+	}
+}
+
+// limitsValues tells whether there are limits on the value of a body, rather than on its size only.
+func (limits *bodyLimits) limitsValues() bool {
+	return limits != nil && (limits.maxDepth > 0 || limits.maxValues > 0 || limits.maxStringLength > 0)
+}
+
+// readAll reads a body whole, failing if it is larger than Options.MaxBodyBytes or, without it, defaultMaxBodyBytes.
+func (limits *bodyLimits) readAll(body io.Reader) ([]byte, error) {
+	maxBytes := int64(defaultMaxBodyBytes)
+	if limits != nil && limits.maxBytes > 0 {
+		maxBytes = limits.maxBytes
+	}
+	data, err := readBody(body, maxBytes)
+	if err != nil {
+		if _, ok := err.(*ParseError); ok {
+			return nil, err
+		}
+		return nil, &ParseError{Kind: KindOther, Cause: err}
+	}
+	return data, nil
+}
+
+// checkDepth checks the nesting depth of an array or object of a body, at a path if known.
+func (limits *bodyLimits) checkDepth(depth int, path []interface{}) error {
+	if limits == nil || limits.maxDepth <= 0 || limits.depth+depth <= limits.maxDepth {
+		return nil
+	}
+	return limitError(path, "body is nested deeper than %d levels", limits.maxDepth)
+}
+
+// countValue counts a value of a body, at a path if known.
+func (limits *bodyLimits) countValue(path []interface{}) error {
+	if limits == nil {
+		return nil
+	}
+	limits.values++
+	if limits.maxValues > 0 && limits.values > limits.maxValues {
+		return limitError(path, "body has more than %d values", limits.maxValues)
+	}
+	return nil
+}
+
+// checkString checks the length of a string or object key of a body, at a path if known.
+func (limits *bodyLimits) checkString(s string, path []interface{}) error {
+	return limits.checkStringLength(len(s), path)
+}
+
+func (limits *bodyLimits) checkStringLength(n int, path []interface{}) error {
+	if limits == nil || limits.maxStringLength <= 0 || n <= limits.maxStringLength {
+		return nil
+	}
+	return limitError(path, "body has a string longer than %d bytes", limits.maxStringLength)
+}
+
+// check checks a value decoded at a depth, such as the value of a body decoded by a decoder
+// that does not enforce limits itself.
+func (limits *bodyLimits) check(value interface{}, depth int, path []interface{}) error {
+	if limits == nil {
+		return nil
+	}
+	if err := limits.countValue(path); err != nil {
+		return err
+	}
+	switch value := value.(type) {
+	case string:
+		return limits.checkString(value, path)
+	case []interface{}:
+		if err := limits.checkDepth(depth+1, path); err != nil {
+			return err
+		}
+		for i, item := range value {
+			if err := limits.check(item, depth+1, appendPath(path, i)); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		if err := limits.checkDepth(depth+1, path); err != nil {
+			return err
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			itemPath := appendPath(path, key)
+			if err := limits.checkString(key, itemPath); err != nil {
+				return err
+			}
+			if err := limits.check(value[key], depth+1, itemPath); err != nil {
+				return err
+			}
+		}
+	case map[interface{}]interface{}:
+		if err := limits.checkDepth(depth+1, path); err != nil {
+			return err
+		}
+		keys := make([]interface{}, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		for _, key := range keys {
+			itemPath := appendPath(path, key)
+			if k, ok := key.(string); ok {
+				if err := limits.checkString(k, itemPath); err != nil {
+					return err
+				}
+			}
+			if err := limits.check(value[key], depth+1, itemPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// appendPath returns a path to an item of the value at a path.
+func appendPath(path []interface{}, item interface{}) []interface{} {
+	// Slice the path to its length so that items do not share its array
+	return append(path[:len(path):len(path)], item)
+}
+
+func limitError(path []interface{}, format string, args ...interface{}) error {
+	return &ParseError{Kind: KindLimitExceeded, Reason: fmt.Sprintf(format, args...), path: path}
+}
+
+// decodeJSONValue decodes the next JSON value of a decoder, nested at a depth and a path,
+// token by token so that limits are enforced as it is decoded.
+func decodeJSONValue(dec *json.Decoder, limits *bodyLimits, depth int, path []interface{}) (interface{}, error) {
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if err := limits.countValue(path); err != nil {
+		return nil, err
+	}
+	switch token := token.(type) {
+	case string:
+		if err := limits.checkString(token, path); err != nil {
+			return nil, err
+		}
+	case json.Delim:
+		if depth++; depth > maxNesting {
+			return nil, fmt.Errorf("exceeded max depth of %d", maxNesting)
+		}
+		if err := limits.checkDepth(depth, path); err != nil {
+			return nil, err
+		}
+		if token == '[' {
+			values := make([]interface{}, 0)
+			for i := 0; dec.More(); i++ {
+				value, err := decodeJSONValue(dec, limits, depth, appendPath(path, i))
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			return values, nil
+		}
+		obj := make(map[string]interface{})
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			k, _ := key.(string)
+			itemPath := appendPath(path, k)
+			if err := limits.checkString(k, itemPath); err != nil {
+				return nil, err
+			}
+			if obj[k], err = decodeJSONValue(dec, limits, depth, itemPath); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+	return token, nil
+}
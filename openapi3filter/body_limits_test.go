@@ -0,0 +1,269 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+func TestValidateRequestBodyLimits(t *testing.T) {
+	requestBody := openapi3.NewRequestBody().WithJSONSchema(openapi3.NewObjectSchema())
+	const body = `{"name":"Rex","tags":["a","b"],"owner":{"name":"Tom"}}`
+
+	testCases := []struct {
+		name       string
+		options    *Options
+		body       string
+		wantErr    *ParseError
+		wantStatus int
+	}{
+		{
+			name:    "within limits",
+			options: &Options{MaxBodyBytes: int64(len(body)), MaxBodyDepth: 2, MaxBodyValues: 8, MaxBodyStringLength: 5},
+			body:    body,
+		},
+		{
+			name:       "too many bytes",
+			options:    &Options{MaxBodyBytes: int64(len(body)) - 1},
+			body:       body,
+			wantErr:    &ParseError{Kind: KindBodyTooLarge},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:       "too deep",
+			options:    &Options{MaxBodyDepth: 1},
+			body:       body,
+			wantErr:    &ParseError{Kind: KindLimitExceeded, path: []interface{}{"tags"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too many values",
+			options:    &Options{MaxBodyValues: 5},
+			body:       body,
+			wantErr:    &ParseError{Kind: KindLimitExceeded, path: []interface{}{"owner"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too long string",
+			options:    &Options{MaxBodyStringLength: 2},
+			body:       body,
+			wantErr:    &ParseError{Kind: KindLimitExceeded, path: []interface{}{"name"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "too long key",
+			options:    &Options{MaxBodyStringLength: 3},
+			body:       `{"name":"Rex"}`,
+			wantErr:    &ParseError{Kind: KindLimitExceeded, path: []interface{}{"name"}},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "limit exceeded before invalid data",
+			options:    &Options{MaxBodyValues: 2},
+			body:       `[1,2,3,`,
+			wantErr:    &ParseError{Kind: KindLimitExceeded, path: []interface{}{1}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/pets", strings.NewReader(tc.body))
+			req.Header.Set(headerCT, "application/json")
+			err := ValidateRequestBody(context.Background(), &RequestValidationInput{Request: req, Options: tc.options}, requestBody)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr))
+			require.Truef(t, matchParseError(parseErr, tc.wantErr), "got error:\n%v\nwant error:\n%v", parseErr, tc.wantErr)
+
+			mockEncoder := &mockErrorEncoder{}
+			encoder := &ValidationErrorEncoder{Encoder: mockEncoder.Encode}
+			encoder.Encode(context.Background(), err, httptest.NewRecorder())
+			var validationErr *ValidationError
+			require.True(t, errors.As(mockEncoder.Err, &validationErr))
+			require.Equal(t, tc.wantStatus, validationErr.Status)
+		})
+	}
+}
+
+func TestValidateRequestBodyLimitsWithoutContentLength(t *testing.T) {
+	requestBody := openapi3.NewRequestBody().WithJSONSchema(openapi3.NewObjectSchema())
+	req := httptest.NewRequest(http.MethodPost, "/pets", ioutil.NopCloser(strings.NewReader(`{"name":"Rex"}`)))
+	req.ContentLength = -1
+	req.Header.Set(headerCT, "application/json")
+	err := ValidateRequestBody(context.Background(), &RequestValidationInput{Request: req, Options: &Options{MaxBodyBytes: 4}}, requestBody)
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, KindBodyTooLarge, parseErr.Kind)
+}
+
+func TestValidateResponseBodyLimits(t *testing.T) {
+	response := openapi3.NewResponse().WithJSONSchema(openapi3.NewArraySchema().WithItems(openapi3.NewIntegerSchema()))
+	input := &ResponseValidationInput{
+		RequestValidationInput: &RequestValidationInput{
+			Request: httptest.NewRequest(http.MethodGet, "/pets", nil),
+			Route: &routers.Route{Operation: &openapi3.Operation{
+				Responses: openapi3.Responses{"200": &openapi3.ResponseRef{Value: response}},
+			}},
+		},
+		Status:  http.StatusOK,
+		Header:  http.Header{headerCT: []string{"application/json"}},
+		Options: &Options{MaxBodyValues: 3},
+	}
+	input.SetBodyBytes([]byte(`[1,2,3]`))
+	err := ValidateResponse(context.Background(), input)
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, KindLimitExceeded, parseErr.Kind)
+	require.Equal(t, []interface{}{2}, parseErr.Path())
+}
+
+func TestDecodeBodyLimits(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mime    string
+		body    string
+		schema  *openapi3.Schema
+		limits  bodyLimits
+		wantErr *ParseError
+	}{
+		{
+			name:    "json too deep",
+			mime:    "application/json",
+			body:    `{"a":{"b":[1]}}`,
+			limits:  bodyLimits{maxDepth: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"a", "b"}},
+		},
+		{
+			name:    "ndjson too many values",
+			mime:    "application/x-ndjson",
+			body:    "{\"a\":1}\n{\"a\":2}\n",
+			limits:  bodyLimits{maxValues: 4},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{2, "a"}},
+		},
+		{
+			name:    "ndjson too deep",
+			mime:    "application/x-ndjson",
+			body:    "1\n[[2]]\n",
+			limits:  bodyLimits{maxDepth: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{2, 0}},
+		},
+		{
+			name:    "yaml too long key",
+			mime:    "application/yaml",
+			body:    "name: Rex\ncolour: brown\n",
+			limits:  bodyLimits{maxStringLength: 5},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"colour"}},
+		},
+		{
+			name:    "yaml too deep",
+			mime:    "application/yaml",
+			body:    "owner:\n  pets: [Rex]\n",
+			limits:  bodyLimits{maxDepth: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"owner", "pets"}},
+		},
+		{
+			name:    "yaml aliases count each time",
+			mime:    "application/yaml",
+			body:    "a: &x [1, 2]\nb: *x\nc: *x\n",
+			limits:  bodyLimits{maxValues: 9},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"c", 1}},
+		},
+		{
+			name:    "yaml larger than MaxBodyBytes",
+			mime:    "application/yaml",
+			body:    "name: Rex\n",
+			limits:  bodyLimits{maxBytes: 4},
+			wantErr: &ParseError{Kind: KindBodyTooLarge},
+		},
+		{
+			name:    "xml too deep",
+			mime:    "application/xml",
+			body:    `<pet><owner><name>Tom</name></owner></pet>`,
+			limits:  bodyLimits{maxDepth: 1},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"owner"}},
+		},
+		{
+			name:    "xml too many values",
+			mime:    "application/xml",
+			body:    `<pet id="1"><name>Rex</name><tag>a</tag></pet>`,
+			limits:  bodyLimits{maxValues: 3},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"tag"}},
+		},
+		{
+			name:    "xml too long text",
+			mime:    "application/xml",
+			body:    `<pet><name>Rex</name></pet>`,
+			limits:  bodyLimits{maxStringLength: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"name"}},
+		},
+		{
+			name:    "csv too many values",
+			mime:    "text/csv",
+			body:    "name,tag\nRex,a\nTom,b\n",
+			limits:  bodyLimits{maxValues: 5},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{3, "name"}},
+		},
+		{
+			name:    "plain too long",
+			mime:    "text/plain",
+			body:    "Rex",
+			limits:  bodyLimits{maxStringLength: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded},
+		},
+		{
+			name: "urlencoded too long",
+			mime: "application/x-www-form-urlencoded",
+			body: "name=Rex",
+			schema: openapi3.NewObjectSchema().WithProperties(map[string]*openapi3.Schema{
+				"name": openapi3.NewStringSchema(),
+			}),
+			limits:  bodyLimits{maxStringLength: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded, path: []interface{}{"name"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header)
+			h.Set(headerCT, tc.mime)
+			var schemaRef *openapi3.SchemaRef
+			if tc.schema != nil {
+				schemaRef = tc.schema.NewRef()
+			}
+			limits := tc.limits
+			_, err := decodeBody(strings.NewReader(tc.body), h, schemaRef, nil, &limits)
+			var parseErr *ParseError
+			require.True(t, errors.As(err, &parseErr), "got error %v", err)
+			require.Truef(t, matchParseError(parseErr, tc.wantErr), "got error:\n%v\nwant error:\n%v", parseErr, tc.wantErr)
+		})
+	}
+}
+
+func TestDecodeBodyLimitsOfRegisteredDecoder(t *testing.T) {
+	const contentType = "application/x-pets"
+	RegisterBodyDecoder(contentType, func(body io.Reader, h http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+		return []interface{}{"Rex", []interface{}{"Tom"}}, nil
+	})
+	defer UnregisterBodyDecoder(contentType)
+
+	h := make(http.Header)
+	h.Set(headerCT, contentType)
+	_, err := decodeBody(strings.NewReader(""), h, nil, nil, &bodyLimits{maxDepth: 1})
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, KindLimitExceeded, parseErr.Kind)
+	require.Equal(t, []interface{}{1}, parseErr.Path())
+}
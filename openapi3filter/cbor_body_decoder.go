@@ -0,0 +1,216 @@
+package openapi3filter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// cborBodyDecoder decodes a CBOR body to the JSON data model: numbers are float64,
+// byte strings are strings of their bytes and undefined is null. Tagged values are decoded
+// as their content, except for bignums which are numbers. Maps must have text string keys.
+func cborBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	data, err := limits.readAll(body)
+	if err != nil {
+		return nil, err
+	}
+	d := &cborDecoder{binaryReader{data: data, limits: limits}}
+	return d.decodeAll(d.decode, "CBOR")
+}
+
+const cborBreak = 0xff
+
+var errCBORBreak = errors.New("unexpected break")
+
+type cborDecoder struct {
+	binaryReader
+}
+
+// decode decodes a data item, counting it as a value.
+func (d *cborDecoder) decode() (interface{}, error) {
+	if err := d.limits.countValue(nil); err != nil {
+		return nil, err
+	}
+	return d.decodeItem()
+}
+
+// decodeItem decodes a data item, a value, a map key or the content of a tag.
+func (d *cborDecoder) decodeItem() (interface{}, error) {
+	b, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := b>>5, b&0x1f
+	if major == 7 {
+		return d.decodeSimple(info)
+	}
+	arg, indefinite, err := d.decodeArgument(info)
+	if err != nil {
+		return nil, err
+	}
+	if indefinite && (major < 2 || major == 6) {
+		return nil, fmt.Errorf("invalid indefinite length of major type %d", major)
+	}
+	if major >= 4 {
+		// Arrays, maps and tagged values
+		if err := d.enter(major != 6); err != nil {
+			return nil, err
+		}
+		defer d.leave(major != 6)
+	}
+	switch major {
+	case 0:
+		return float64(arg), nil
+	case 1:
+		return -1 - float64(arg), nil
+	case 2, 3:
+		var s []byte
+		if indefinite {
+			// The chunks of the string are definite-length strings of its type
+			for !d.atBreak() {
+				chunk, err := d.readByte()
+				if err != nil {
+					return nil, err
+				}
+				n, chunkIndefinite, err := d.decodeArgument(chunk & 0x1f)
+				if err != nil {
+					return nil, err
+				}
+				if chunk>>5 != major || chunkIndefinite {
+					return nil, errors.New("invalid chunk of indefinite-length string")
+				}
+				b, err := d.next(n)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, b...)
+			}
+		} else if s, err = d.next(arg); err != nil {
+			return nil, err
+		}
+		if major == 3 && !utf8.Valid(s) {
+			return nil, errors.New("invalid UTF-8 text string")
+		}
+		if err := d.limits.checkStringLength(len(s), nil); err != nil {
+			return nil, err
+		}
+		return string(s), nil
+	case 4:
+		values := make([]interface{}, 0, d.capacity(arg))
+		for i := uint64(0); indefinite && !d.atBreak() || !indefinite && i < arg; i++ {
+			value, err := d.decode()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case 5:
+		obj := make(map[string]interface{}, d.capacity(arg))
+		for i := uint64(0); indefinite && !d.atBreak() || !indefinite && i < arg; i++ {
+			key, err := d.decodeItem()
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("map key %v is not a string", key)
+			}
+			if obj[k], err = d.decode(); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	default: // 6
+		value, err := d.decodeItem()
+		if err != nil {
+			return nil, err
+		}
+		if arg == 2 || arg == 3 {
+			s, ok := value.(string)
+			if !ok {
+				return nil, errors.New("invalid bignum")
+			}
+			v, _ := new(big.Float).SetInt(new(big.Int).SetBytes([]byte(s))).Float64()
+			if arg == 3 {
+				v = -1 - v
+			}
+			return v, nil
+		}
+		return value, nil
+	}
+}
+
+// decodeArgument decodes the argument of a data item, or tells whether the data item has an indefinite length.
+func (d *cborDecoder) decodeArgument(info byte) (uint64, bool, error) {
+	switch {
+	case info < 24:
+		return uint64(info), false, nil
+	case info <= 27:
+		v, err := d.readUint(1 << (info - 24))
+		return v, false, err
+	case info == 31:
+		return 0, true, nil
+	}
+	return 0, false, fmt.Errorf("invalid additional information %d", info)
+}
+
+// decodeSimple decodes a simple value or a float.
+func (d *cborDecoder) decodeSimple(info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		v, err := d.readUint(2)
+		return float16ToFloat64(uint16(v)), err
+	case 26:
+		v, err := d.readUint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 27:
+		v, err := d.readUint(8)
+		return math.Float64frombits(v), err
+	case 31:
+		return nil, errCBORBreak
+	}
+	return nil, fmt.Errorf("unsupported simple value %d", info)
+}
+
+// atBreak tells whether the next byte is the break ending an indefinite-length data item, and skips it if it is.
+func (d *cborDecoder) atBreak() bool {
+	if d.off < len(d.data) && d.data[d.off] == cborBreak {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func float16ToFloat64(h uint16) float64 {
+	exp, frac := int(h>>10&0x1f), float64(h&0x3ff)
+	var v float64
+	switch exp {
+	case 0:
+		v = math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			v = math.Inf(1)
+		} else {
+			v = math.NaN()
+		}
+	default:
+		v = math.Ldexp(frac+0x400, exp-25)
+	}
+	if h&0x8000 != 0 {
+		v = -v
+	}
+	return v
+}
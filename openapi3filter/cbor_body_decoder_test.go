@@ -0,0 +1,132 @@
+package openapi3filter
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCBORBodyDecoder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mime    string
+		body    string
+		limits  *bodyLimits
+		want    interface{}
+		wantErr *ParseError
+	}{
+		{
+			name: "map",
+			body: "\xa9" +
+				"\x62id\x01" +
+				"\x64name\x63Rex" +
+				"\x64tags\x9f\x61a\xff" +
+				"\x62ok\xf5" +
+				"\x61n\xf6" +
+				"\x61f\xf9\x3e\x00" +
+				"\x63neg\x22" +
+				"\x63big\xc2\x42\x01\x00" +
+				"\x63txt\x7f\x62ab\x61c\xff",
+			want: map[string]interface{}{
+				"id":   1.0,
+				"name": "Rex",
+				"tags": []interface{}{"a"},
+				"ok":   true,
+				"n":    nil,
+				"f":    1.5,
+				"neg":  -3.0,
+				"big":  256.0,
+				"txt":  "abc",
+			},
+		},
+		{
+			name: "suffix",
+			mime: "application/vnd.acme+cbor",
+			body: "\x82\xfa\x3f\xc0\x00\x00\xc1\x19\x01\x00",
+			want: []interface{}{1.5, 256.0},
+		},
+		{
+			name: "indefinite map",
+			body: "\xbf\x61a\x18\x2a\xff",
+			want: map[string]interface{}{"a": 42.0},
+		},
+		{
+			name:    "invalid text",
+			body:    "\x61\xff",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "truncated",
+			body:    "\x62a",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "key is not a string",
+			body:    "\xa1\x01\x01",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "unexpected break",
+			body:    "\xff",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "trailing data",
+			body:    "\x01\x01",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "unterminated array",
+			body:    "\x9f\x01",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "deeply nested",
+			body:    strings.Repeat("\x81", 1<<20) + "\xf6",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "deeply nested tags",
+			body:    strings.Repeat("\xc6", 1<<20) + "\xf6",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "nested deeper than the limit",
+			body:    "\x81\xa1\x61a\x9f\xf6\xff",
+			limits:  &bodyLimits{maxDepth: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded},
+		},
+		{
+			name:   "tags do not count toward the limit",
+			body:   "\x81\xc6\xa1\x61a\xf6",
+			limits: &bodyLimits{maxDepth: 2},
+			want:   []interface{}{map[string]interface{}{"a": nil}},
+		},
+		{
+			name:    "larger than MaxBodyBytes",
+			body:    "\x81\xf6",
+			limits:  &bodyLimits{maxBytes: 1},
+			wantErr: &ParseError{Kind: KindBodyTooLarge},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mime := tc.mime
+			if mime == "" {
+				mime = "application/cbor"
+			}
+			h := make(http.Header)
+			h.Set(headerCT, mime)
+			got, err := decodeBody(strings.NewReader(tc.body), h, nil, nil, tc.limits)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
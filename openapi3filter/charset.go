@@ -0,0 +1,139 @@
+package openapi3filter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"strings"
+	"unicode/utf16"
+)
+
+// CharsetDecoder returns a reader of the UTF-8 encoding of the text a reader reads in a charset.
+type CharsetDecoder func(io.Reader) (io.Reader, error)
+
+// charsetDecoders contains decoders for the charsets of bodies, by lowercased name.
+// Bodies in UTF-8 or US-ASCII are not decoded.
+var charsetDecoders = map[string]CharsetDecoder{
+	"iso-8859-1": latin1Decoder,
+	"iso_8859-1": latin1Decoder,
+	"latin1":     latin1Decoder,
+	"l1":         latin1Decoder,
+	"utf-16":     utf16Decoder(nil),
+	"utf-16be":   utf16Decoder(utf16BigEndian),
+	"utf-16le":   utf16Decoder(utf16LittleEndian),
+}
+
+// RegisterCharsetDecoder registers a decoder for the charset of bodies, such as "windows-1252",
+// given in the charset parameter of their Content-Type. Text bodies, such as JSON, XML, YAML
+// and text/* ones, are decoded to UTF-8 before their body decoder decodes them; bodies in a charset
+// without a decoder are passed to it as they are. Bodies in UTF-8, US-ASCII, ISO-8859-1 and UTF-16
+// are supported without registering decoders.
+// This call is not thread-safe: charset decoders should not be created/destroyed by multiple goroutines.
+func RegisterCharsetDecoder(charset string, decoder CharsetDecoder) {
+	if charset == "" {
+		panic("charset is empty")
+	}
+	if decoder == nil {
+		panic("decoder is not defined")
+	}
+	charsetDecoders[strings.ToLower(charset)] = decoder
+}
+
+// mediaTypeCharset returns the charset parameter of a Content-Type, if any.
+func mediaTypeCharset(contentType string) string {
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return params["charset"]
+}
+
+// isTextMediaType tells whether the bodies of a media type are text, which the charset
+// of their Content-Type applies to, rather than binary data such as MessagePack.
+func isTextMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if strings.HasPrefix(mediaType, "text/") {
+		return true
+	}
+	if j := strings.LastIndexByte(mediaType, '+'); j >= 0 {
+		switch mediaType[j+1:] {
+		case "json", "xml", "yaml":
+			return true
+		}
+	}
+	switch mediaType {
+	case "application/json", "application/x-ndjson", "application/xml", "application/yaml", "application/x-yaml":
+		return true
+	}
+	return false
+}
+
+// charsetReader returns a reader of the UTF-8 encoding of a body in a charset.
+// Bodies in a charset without a decoder are read as they are.
+func charsetReader(charset string, body io.Reader) (io.Reader, error) {
+	switch name := strings.ToLower(charset); name {
+	case "utf-8", "utf8", "us-ascii", "ascii":
+		return body, nil
+	default:
+		decoder, ok := charsetDecoders[name]
+		if !ok {
+			return body, nil
+		}
+		r, err := decoder(body)
+		if err != nil {
+			return nil, &ParseError{Kind: KindInvalidFormat, Reason: fmt.Sprintf("invalid %s text", charset), Cause: err}
+		}
+		return r, nil
+	}
+}
+
+func latin1Decoder(body io.Reader) (io.Reader, error) {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	var buf strings.Builder
+	buf.Grow(len(data))
+	for _, b := range data {
+		buf.WriteRune(rune(b))
+	}
+	return strings.NewReader(buf.String()), nil
+}
+
+type utf16ByteOrder func(b []byte) uint16
+
+func utf16BigEndian(b []byte) uint16    { return uint16(b[0])<<8 | uint16(b[1]) }
+func utf16LittleEndian(b []byte) uint16 { return uint16(b[1])<<8 | uint16(b[0]) }
+
+// utf16Decoder returns a decoder of UTF-16 in a byte order or,
+// without one, in the byte order of the byte order mark, big-endian by default.
+func utf16Decoder(order utf16ByteOrder) CharsetDecoder {
+	return func(body io.Reader) (io.Reader, error) {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		if len(data)%2 != 0 {
+			return nil, errors.New("odd number of bytes")
+		}
+		order := order
+		if order == nil {
+			order = utf16BigEndian
+			if len(data) >= 2 {
+				switch {
+				case data[0] == 0xFE && data[1] == 0xFF:
+					data = data[2:]
+				case data[0] == 0xFF && data[1] == 0xFE:
+					order, data = utf16LittleEndian, data[2:]
+				}
+			}
+		}
+		units := make([]uint16, 0, len(data)/2)
+		for i := 0; i < len(data); i += 2 {
+			units = append(units, order(data[i:]))
+		}
+		return strings.NewReader(string(utf16.Decode(units))), nil
+	}
+}
@@ -0,0 +1,80 @@
+package openapi3filter
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// csvBodyDecoder decodes a CSV body to an array of objects, one per row, whose properties
+// are named by the header row. Values are converted to the types of the properties of
+// the schema's items, and empty values of properties that are not strings are left out.
+// Errors have the number of their row, counting the header row, and the name of their column in their path.
+func csvBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	if err := limits.countValue(nil); err != nil {
+		return nil, err
+	}
+	r := csv.NewReader(body)
+	r.ReuseRecord = true
+	names, err := r.Read()
+	if err == io.EOF {
+		return []interface{}{}, nil
+	}
+	if err != nil {
+		return nil, &ParseError{Kind: KindInvalidFormat, Cause: err, path: []interface{}{1}}
+	}
+	names = append([]string(nil), names...)
+	for _, name := range names {
+		if err := limits.checkString(name, []interface{}{1, name}); err != nil {
+			return nil, err
+		}
+	}
+
+	var properties openapi3.Schemas
+	if s := schemaValue(schema); s != nil && s.Items != nil && s.Items.Value != nil {
+		properties = schemaProperties(s.Items.Value)
+	}
+	values := make([]interface{}, 0)
+	for row := 2; ; row++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, &ParseError{Kind: KindInvalidFormat, Cause: err, path: []interface{}{row}}
+		}
+		if err := limits.checkDepth(2, []interface{}{row}); err != nil {
+			return nil, err
+		}
+		if err := limits.countValue([]interface{}{row}); err != nil {
+			return nil, err
+		}
+		obj := make(map[string]interface{}, len(names))
+		for i, name := range names {
+			value, err := csvValue(record[i], properties[name])
+			if err != nil {
+				return nil, &ParseError{Kind: KindInvalidFormat, Value: record[i], Cause: err, path: []interface{}{row, name}}
+			}
+			if value != nil {
+				if err := limits.check(value, 2, []interface{}{row, name}); err != nil {
+					return nil, err
+				}
+				obj[name] = value
+			}
+		}
+		values = append(values, obj)
+	}
+}
+
+func csvValue(raw string, schema *openapi3.SchemaRef) (interface{}, error) {
+	s := schemaValue(schema)
+	if s == nil {
+		return raw, nil
+	}
+	if raw == "" && s.Type != "" && s.Type != "string" {
+		return nil, nil
+	}
+	return s.CoerceValue(raw)
+}
@@ -0,0 +1,97 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func newCSVTestSchema() *openapi3.Schema {
+	items := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewIntegerSchema()).
+		WithProperty("name", openapi3.NewStringSchema()).
+		WithProperty("score", openapi3.NewFloat64Schema()).
+		WithProperty("active", openapi3.NewBoolSchema())
+	items.Required = []string{"id"}
+	return openapi3.NewArraySchema().WithItems(items)
+}
+
+func TestCSVBodyDecoder(t *testing.T) {
+	schema := newCSVTestSchema().NewRef()
+	testCases := []struct {
+		name    string
+		body    string
+		want    interface{}
+		wantErr *ParseError
+	}{
+		{
+			name: "rows",
+			body: "id,name,score,active,notes\n1,Rex,1.5,true,\"a\nb\"\n2,,,,\n",
+			want: []interface{}{
+				map[string]interface{}{"id": 1.0, "name": "Rex", "score": 1.5, "active": true, "notes": "a\nb"},
+				map[string]interface{}{"id": 2.0, "name": "", "notes": ""},
+			},
+		},
+		{
+			name: "header only",
+			body: "id,name\n",
+			want: []interface{}{},
+		},
+		{
+			name: "empty",
+			body: "",
+			want: []interface{}{},
+		},
+		{
+			name:    "invalid value",
+			body:    "id,name\n1,Rex\ntwo,Tom\n",
+			wantErr: &ParseError{Kind: KindInvalidFormat, Value: "two", path: []interface{}{3, "id"}},
+		},
+		{
+			name:    "missing value",
+			body:    "id,name\n1\n",
+			wantErr: &ParseError{Kind: KindInvalidFormat, path: []interface{}{2}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header)
+			h.Set(headerCT, "text/csv")
+			got, err := decodeBody(strings.NewReader(tc.body), h, schema, nil, nil)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateCSVRequestBody(t *testing.T) {
+	requestBody := openapi3.NewRequestBody().WithContent(openapi3.Content{
+		"text/csv": openapi3.NewMediaType().WithSchema(newCSVTestSchema()),
+	})
+
+	validate := func(body string) error {
+		req, err := http.NewRequest(http.MethodPost, "/scores", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(headerCT, "text/csv; charset=utf-8")
+		return ValidateRequestBody(context.Background(), &RequestValidationInput{Request: req}, requestBody)
+	}
+
+	require.NoError(t, validate("id,score\n1,2.5\n"))
+
+	err := validate("id,score\n1,2.5\n,3\n")
+	require.Error(t, err)
+	var schemaErr *openapi3.SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	require.Equal(t, "required", schemaErr.SchemaField)
+}
@@ -45,7 +45,9 @@ var _ error = &ResponseError{}
 
 // ResponseError is returned by ValidateResponse when response does not match OpenAPI spec
 type ResponseError struct {
-	Input  *ResponseValidationInput
+	Input *ResponseValidationInput
+	// Header is the name of the header with the error, if any
+	Header string
 	Reason string
 	Err    error
 }
@@ -61,6 +63,9 @@ func (err *ResponseError) Error() string {
 			reason += ": " + e.Error()
 		}
 	}
+	if err.Header != "" {
+		return fmt.Sprintf("response header %q has an error: %s", err.Header, reason)
+	}
 	return reason
 }
 
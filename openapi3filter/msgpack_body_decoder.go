@@ -0,0 +1,288 @@
+package openapi3filter
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// msgpackBodyDecoder decodes a MessagePack body to the JSON data model: numbers are float64,
+// binary data is a string of its bytes and timestamps are RFC 3339 strings.
+// Maps must have string keys, and other extension types are not supported.
+func msgpackBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	data, err := limits.readAll(body)
+	if err != nil {
+		return nil, err
+	}
+	d := &msgpackDecoder{binaryReader{data: data, limits: limits}}
+	return d.decodeAll(d.decode, "MessagePack")
+}
+
+// binaryReader reads values in a binary format, such as MessagePack or CBOR.
+type binaryReader struct {
+	data []byte
+	off  int
+
+	limits *bodyLimits
+	// nesting is the number of data items the one being decoded is nested in,
+	// and depth the number of arrays and maps among them.
+	nesting int
+	depth   int
+}
+
+// decodeAll decodes the only value of the data, returning a ParseError with the offset of the first error.
+func (r *binaryReader) decodeAll(decode func() (interface{}, error), format string) (interface{}, error) {
+	value, err := decode()
+	if err == nil && r.off != len(r.data) {
+		err = errors.New("unexpected data after the value")
+	}
+	if err != nil {
+		if _, ok := err.(*ParseError); ok {
+			return nil, err
+		}
+		return nil, &ParseError{Kind: KindInvalidFormat, Reason: fmt.Sprintf("invalid %s at offset %d", format, r.off), Cause: err}
+	}
+	return value, nil
+}
+
+// enter enters a data item which other data items are nested in, an array or a map if container is set.
+func (r *binaryReader) enter(container bool) error {
+	if r.nesting++; r.nesting > maxNesting {
+		return &ParseError{
+			Kind:   KindInvalidFormat,
+			Reason: fmt.Sprintf("data items are nested deeper than %d levels at offset %d", maxNesting, r.off),
+		}
+	}
+	if container {
+		r.depth++
+		return r.limits.checkDepth(r.depth, nil)
+	}
+	return nil
+}
+
+// leave leaves the data item entered last.
+func (r *binaryReader) leave(container bool) {
+	r.nesting--
+	if container {
+		r.depth--
+	}
+}
+
+func (r *binaryReader) next(n uint64) ([]byte, error) {
+	if n > uint64(len(r.data)-r.off) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	b := r.data[r.off : r.off+int(n)]
+	r.off += int(n)
+	return b, nil
+}
+
+func (r *binaryReader) readByte() (byte, error) {
+	b, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+// readUint reads a big-endian unsigned integer of n bytes.
+func (r *binaryReader) readUint(n uint64) (uint64, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v, nil
+}
+
+// capacity returns a capacity for n values, which take a byte at least each.
+func (r *binaryReader) capacity(n uint64) int {
+	if remaining := uint64(len(r.data) - r.off); n > remaining {
+		return int(remaining)
+	}
+	return int(n)
+}
+
+type msgpackDecoder struct {
+	binaryReader
+}
+
+// decode decodes a value, counting it.
+func (d *msgpackDecoder) decode() (interface{}, error) {
+	if err := d.limits.countValue(nil); err != nil {
+		return nil, err
+	}
+	return d.decodeItem()
+}
+
+// decodeItem decodes a value or a map key.
+func (d *msgpackDecoder) decodeItem() (interface{}, error) {
+	b, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case b <= 0x7f:
+		return float64(b), nil
+	case b >= 0xe0:
+		return float64(int8(b)), nil
+	case b&0xf0 == 0x80:
+		return d.decodeMap(uint64(b & 0x0f))
+	case b&0xf0 == 0x90:
+		return d.decodeArray(uint64(b & 0x0f))
+	case b&0xe0 == 0xa0:
+		return d.decodeString(uint64(b & 0x1f))
+	}
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6: // bin 8-32
+		n, err := d.readUint(1 << (b - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(n)
+	case 0xd9, 0xda, 0xdb: // str 8-32
+		n, err := d.readUint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(n)
+	case 0xc7, 0xc8, 0xc9: // ext 8-32
+		n, err := d.readUint(1 << (b - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(n)
+	case 0xca:
+		v, err := d.readUint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := d.readUint(8)
+		return math.Float64frombits(v), err
+	case 0xcc, 0xcd, 0xce, 0xcf: // uint 8-64
+		v, err := d.readUint(1 << (b - 0xcc))
+		return float64(v), err
+	case 0xd0, 0xd1, 0xd2, 0xd3: // int 8-64
+		n := uint64(1) << (b - 0xd0)
+		v, err := d.readUint(n)
+		// Sign-extend the integer
+		shift := 64 - 8*n
+		return float64(int64(v<<shift) >> shift), err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8: // fixext 1-16
+		return d.decodeExt(1 << (b - 0xd4))
+	case 0xdc, 0xdd: // array 16-32
+		n, err := d.readUint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n)
+	case 0xde, 0xdf: // map 16-32
+		n, err := d.readUint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n)
+	}
+	return nil, fmt.Errorf("invalid type 0x%02x", b)
+}
+
+func (d *msgpackDecoder) decodeString(n uint64) (interface{}, error) {
+	b, err := d.next(n)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.limits.checkStringLength(len(b), nil); err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *msgpackDecoder) decodeArray(n uint64) (interface{}, error) {
+	if err := d.enter(true); err != nil {
+		return nil, err
+	}
+	defer d.leave(true)
+	values := make([]interface{}, 0, d.capacity(n))
+	for i := uint64(0); i < n; i++ {
+		value, err := d.decode()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (d *msgpackDecoder) decodeMap(n uint64) (interface{}, error) {
+	if err := d.enter(true); err != nil {
+		return nil, err
+	}
+	defer d.leave(true)
+	obj := make(map[string]interface{}, d.capacity(n))
+	for i := uint64(0); i < n; i++ {
+		key, err := d.decodeItem()
+		if err != nil {
+			return nil, err
+		}
+		k, ok := key.(string)
+		if !ok {
+			return nil, fmt.Errorf("map key %v is not a string", key)
+		}
+		if obj[k], err = d.decode(); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+// decodeExt decodes an extension type of n bytes. Only timestamps, of type -1, are supported.
+func (d *msgpackDecoder) decodeExt(n uint64) (interface{}, error) {
+	typ, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if int8(typ) != -1 {
+		return nil, fmt.Errorf("unsupported extension type %d", int8(typ))
+	}
+	var sec, nsec int64
+	switch n {
+	case 4:
+		v, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		sec = int64(v)
+	case 8:
+		v, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		sec, nsec = int64(v&0x3ffffffff), int64(v>>34)
+	case 12:
+		v, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		s, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		sec, nsec = int64(s), int64(v)
+	default:
+		return nil, fmt.Errorf("invalid timestamp of %d bytes", n)
+	}
+	return time.Unix(sec, nsec).UTC().Format(time.RFC3339Nano), nil
+}
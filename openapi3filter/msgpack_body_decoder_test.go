@@ -0,0 +1,120 @@
+package openapi3filter
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMsgpackBodyDecoder(t *testing.T) {
+	testCases := []struct {
+		name    string
+		body    string
+		limits  *bodyLimits
+		want    interface{}
+		wantErr *ParseError
+	}{
+		{
+			name: "map",
+			body: "\x8b" +
+				"\xa2id\x01" +
+				"\xa4name\xa3Rex" +
+				"\xa4tags\x91\xa1a" +
+				"\xa2ok\xc3" +
+				"\xa1n\xc0" +
+				"\xa1f\xcb\x3f\xf8\x00\x00\x00\x00\x00\x00" +
+				"\xa3neg\xfd" +
+				"\xa3big\xcd\x01\x2c" +
+				"\xa1i\xd0\x9c" +
+				"\xa3bin\xc4\x02AB" +
+				"\xa2ts\xd6\xff\x00\x00\x00\x3c",
+			want: map[string]interface{}{
+				"id":   1.0,
+				"name": "Rex",
+				"tags": []interface{}{"a"},
+				"ok":   true,
+				"n":    nil,
+				"f":    1.5,
+				"neg":  -3.0,
+				"big":  300.0,
+				"i":    -100.0,
+				"bin":  "AB",
+				"ts":   "1970-01-01T00:01:00Z",
+			},
+		},
+		{
+			name: "long array",
+			body: "\xdc\x00\x02\xca\x3f\xc0\x00\x00\xd9\x01x",
+			want: []interface{}{1.5, "x"},
+		},
+		{
+			name:    "truncated",
+			body:    "\xa3Re",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "key is not a string",
+			body:    "\x81\x01\x01",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "unsupported extension",
+			body:    "\xd4\x01\x00",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "never used type",
+			body:    "\xc1",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "trailing data",
+			body:    "\x01\x02",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "declared length beyond data",
+			body:    "\xdd\xff\xff\xff\xff\x01",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "deeply nested",
+			body:    strings.Repeat("\x91", 1<<20) + "\xc0",
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "nested deeper than the limit",
+			body:    "\x91\x81\xa1a\x91\xc0",
+			limits:  &bodyLimits{maxDepth: 2},
+			wantErr: &ParseError{Kind: KindLimitExceeded},
+		},
+		{
+			name:   "nested as deep as the limit",
+			body:   "\x91\x81\xa1a\xc0",
+			limits: &bodyLimits{maxDepth: 2},
+			want:   []interface{}{map[string]interface{}{"a": nil}},
+		},
+		{
+			name:    "larger than MaxBodyBytes",
+			body:    "\x91\xc0",
+			limits:  &bodyLimits{maxBytes: 1},
+			wantErr: &ParseError{Kind: KindBodyTooLarge},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header)
+			h.Set(headerCT, "application/msgpack")
+			got, err := decodeBody(strings.NewReader(tc.body), h, nil, nil, tc.limits)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package openapi3filter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ndjsonBodyDecoder decodes a body of newline-delimited JSON values to an array of them.
+// Empty lines are skipped. Errors have the line number of their value in their path.
+func ndjsonBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	if err := limits.countValue(nil); err != nil {
+		return nil, err
+	}
+	if err := limits.checkDepth(1, nil); err != nil {
+		return nil, err
+	}
+	r := bufio.NewReader(body)
+	values := make([]interface{}, 0)
+	for line := 1; ; line++ {
+		data, err := r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, &ParseError{Kind: KindOther, Cause: err, path: []interface{}{line}}
+		}
+		if data := bytes.TrimSpace(data); len(data) != 0 {
+			value, err := ndjsonValue(data, limits, line)
+			if err != nil {
+				if _, ok := err.(*ParseError); ok {
+					return nil, err
+				}
+				return nil, &ParseError{Kind: KindInvalidFormat, Cause: err, path: []interface{}{line}}
+			}
+			values = append(values, value)
+		}
+		if err == io.EOF {
+			return values, nil
+		}
+	}
+}
+
+// ndjsonValue decodes the JSON value of a line, within limits if any.
+func ndjsonValue(data []byte, limits *bodyLimits, line int) (interface{}, error) {
+	var value interface{}
+	if !limits.limitsValues() {
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	value, err := decodeJSONValue(dec, limits, 1, []interface{}{line})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after the value")
+	}
+	return value, nil
+}
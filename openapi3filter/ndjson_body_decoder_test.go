@@ -0,0 +1,60 @@
+package openapi3filter
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestNDJSONBodyDecoder(t *testing.T) {
+	schema := openapi3.NewArraySchema().WithItems(openapi3.NewObjectSchema()).NewRef()
+	testCases := []struct {
+		name    string
+		body    string
+		want    interface{}
+		wantErr *ParseError
+	}{
+		{
+			name: "values",
+			body: "{\"id\":1}\n\n{\"id\":2}\r\n\"three\"",
+			want: []interface{}{
+				map[string]interface{}{"id": 1.0},
+				map[string]interface{}{"id": 2.0},
+				"three",
+			},
+		},
+		{
+			name: "empty",
+			body: "",
+			want: []interface{}{},
+		},
+		{
+			name:    "invalid line",
+			body:    "{\"id\":1}\n{id}\n",
+			wantErr: &ParseError{Kind: KindInvalidFormat, path: []interface{}{2}},
+		},
+		{
+			name:    "several values on a line",
+			body:    "{\"id\":1}\n\n{\"id\":2} {\"id\":3}\n",
+			wantErr: &ParseError{Kind: KindInvalidFormat, path: []interface{}{3}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header)
+			h.Set(headerCT, "application/x-ndjson")
+			got, err := decodeBody(strings.NewReader(tc.body), h, schema, nil, nil)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -19,6 +19,36 @@ type Options struct {
 
 	MultiError bool
 
+	// Set RejectReadOnly so ValidateRequest fails on readOnly properties
+	// being sent instead of ignoring them
+	RejectReadOnly bool
+
+	// Set RejectUnknownProperties so validation fails on object properties
+	// not declared by their schema, including through allOf, oneOf and anyOf
+	RejectUnknownProperties bool
+
+	// Set RejectNotAcceptable so ValidateRequest fails with ErrNotAcceptable when the request's
+	// Accept header accepts none of the media types of the operation's successful responses,
+	// and ValidateResponse fails when it does not accept the response's Content-Type
+	RejectNotAcceptable bool
+
+	// Set MaxBodyBytes so validation fails with a ParseError of kind KindBodyTooLarge
+	// on bodies larger than this many bytes, without reading them further.
+	// Without it, MessagePack, CBOR and YAML bodies, which are read whole before they are decoded,
+	// fail the same way when they are larger than 32 MiB.
+	MaxBodyBytes int64
+
+	// Set MaxBodyDepth, MaxBodyValues and MaxBodyStringLength so validation fails
+	// with a ParseError of kind KindLimitExceeded on bodies that decode to values nested
+	// deeper than this many arrays and objects, to more than this many values in all,
+	// or with strings, including object keys, longer than this many bytes.
+	// Built-in body decoders fail as soon as a limit is exceeded, except for YAML bodies,
+	// which are checked once parsed; values of decoders registered with RegisterBodyDecoder
+	// are checked once decoded.
+	MaxBodyDepth        int
+	MaxBodyValues       int
+	MaxBodyStringLength int
+
 	// See NoopAuthenticationFunc
 	AuthenticationFunc AuthenticationFunc
 }
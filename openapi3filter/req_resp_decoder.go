@@ -1,6 +1,7 @@
 package openapi3filter
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -11,7 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
-	"strconv"
+	"sort"
 	"strings"
 
 	"gopkg.in/yaml.v2"
@@ -31,6 +32,11 @@ const (
 	// KindInvalidFormat describes an error that happens when a value does not conform a format
 	// that is required by a serialization method.
 	KindInvalidFormat
+	// KindBodyTooLarge describes an error that happens when a body is larger than Options.MaxBodyBytes.
+	KindBodyTooLarge
+	// KindLimitExceeded describes an error that happens when a body decodes to a value
+	// that exceeds Options.MaxBodyDepth, Options.MaxBodyValues or Options.MaxBodyStringLength.
+	KindLimitExceeded
 )
 
 // ParseError describes errors which happens while parse operation's parameters, requestBody, or response.
@@ -792,29 +798,15 @@ func parsePrimitive(raw string, schema *openapi3.SchemaRef) (interface{}, error)
 		return nil, nil
 	}
 	switch schema.Value.Type {
-	case "integer":
-		v, err := strconv.ParseFloat(raw, 64)
-		if err != nil {
-			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid integer", Cause: err}
-		}
-		return v, nil
-	case "number":
-		v, err := strconv.ParseFloat(raw, 64)
-		if err != nil {
-			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid number", Cause: err}
-		}
-		return v, nil
-	case "boolean":
-		v, err := strconv.ParseBool(raw)
-		if err != nil {
-			return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid number", Cause: err}
-		}
-		return v, nil
-	case "string":
-		return raw, nil
+	case "integer", "number", "boolean", "string":
 	default:
 		panic(fmt.Sprintf("schema has non primitive type %q", schema.Value.Type))
 	}
+	v, err := schema.Value.CoerceValue(raw)
+	if err != nil {
+		return nil, &ParseError{Kind: KindInvalidFormat, Value: raw, Reason: "an invalid " + schema.Value.Type, Cause: err}
+	}
+	return v, nil
 }
 
 // EncodingFn is a function that returns an encoding of a request body's part.
@@ -828,6 +820,21 @@ type BodyDecoder func(io.Reader, http.Header, *openapi3.SchemaRef, EncodingFn) (
 // By default, there is content type "application/json" is supported only.
 var bodyDecoders = make(map[string]BodyDecoder)
 
+// limitedBodyDecoder is a body decoder that enforces limits on the value of a body as it decodes it.
+type limitedBodyDecoder func(io.Reader, http.Header, *openapi3.SchemaRef, EncodingFn, *bodyLimits) (interface{}, error)
+
+// limitedBodyDecoders contains the built-in body decoders that enforce limits, by content type.
+// A content type is removed from it when another decoder is registered for it.
+var limitedBodyDecoders = make(map[string]limitedBodyDecoder)
+
+// registerLimitedBodyDecoder registers a built-in body decoder that enforces limits for a content type.
+func registerLimitedBodyDecoder(contentType string, decoder limitedBodyDecoder) {
+	RegisterBodyDecoder(contentType, func(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+		return decoder(body, header, schema, encFn, nil)
+	})
+	limitedBodyDecoders[contentType] = decoder
+}
+
 // RegisteredBodyDecoder returns the registered body decoder for the given content type.
 //
 // If no decoder was registered for the given content type, nil is returned.
@@ -837,6 +844,8 @@ func RegisteredBodyDecoder(contentType string) BodyDecoder {
 }
 
 // RegisterBodyDecoder registers a request body's decoder for a content type.
+// The content type may be a range, such as "text/*" or "*/*": its decoder decodes the content types
+// in the range that have no decoder of their own.
 //
 // If a decoder for the specified content type already exists, the function replaces
 // it with the specified decoder.
@@ -849,6 +858,7 @@ func RegisterBodyDecoder(contentType string, decoder BodyDecoder) {
 		panic("decoder is not defined")
 	}
 	bodyDecoders[contentType] = decoder
+	delete(limitedBodyDecoders, contentType)
 }
 
 // UnregisterBodyDecoder dissociates a body decoder from a content type.
@@ -860,15 +870,55 @@ func UnregisterBodyDecoder(contentType string) {
 		panic("contentType is empty")
 	}
 	delete(bodyDecoders, contentType)
+	delete(limitedBodyDecoders, contentType)
+}
+
+// structuredSyntaxSuffixes maps the structured syntax suffixes of media types,
+// such as "json" in "application/hal+json", to the media types whose body decoder they use.
+var structuredSyntaxSuffixes = map[string]string{
+	"json": "application/json",
+	"cbor": "application/cbor",
+	"xml":  "application/xml",
+	"yaml": "application/yaml",
+}
+
+// bodyDecoderFor returns the content type whose body decoder decodes a media type: the media type
+// if it has a decoder or, if it has none, the media type of its structured syntax suffix, such as
+// "application/json" for "application/hal+json", or a range the media type is in, "type/*" then "*/*".
+func bodyDecoderFor(mediaType string) (string, bool) {
+	if _, ok := bodyDecoders[mediaType]; ok {
+		return mediaType, true
+	}
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if _, ok := bodyDecoders[mediaType]; ok {
+		return mediaType, true
+	}
+	i := strings.IndexByte(mediaType, '/')
+	if i <= 0 {
+		return "", false
+	}
+	if j := strings.LastIndexByte(mediaType, '+'); j > i {
+		suffixType := structuredSyntaxSuffixes[mediaType[j+1:]]
+		if _, ok := bodyDecoders[suffixType]; ok {
+			return suffixType, true
+		}
+	}
+	for _, mediaRange := range []string{mediaType[:i] + "/*", "*/*"} {
+		if _, ok := bodyDecoders[mediaRange]; ok {
+			return mediaRange, true
+		}
+	}
+	return "", false
 }
 
 var headerCT = http.CanonicalHeaderKey("Content-Type")
 
 const prefixUnsupportedCT = "unsupported content type"
 
-// decodeBody returns a decoded body.
-// The function returns ParseError when a body is invalid.
-func decodeBody(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+// decodeBody returns a decoded body. Built-in body decoders enforce limits as they decode it,
+// and the value other decoders decode is checked against them.
+// The function returns ParseError when a body is invalid or exceeds the limits.
+func decodeBody(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
 	contentType := header.Get(headerCT)
 	if contentType == "" {
 		if _, ok := body.(*multipart.Part); ok {
@@ -876,56 +926,99 @@ func decodeBody(body io.Reader, header http.Header, schema *openapi3.SchemaRef,
 		}
 	}
 	mediaType := parseMediaType(contentType)
-	decoder, ok := bodyDecoders[mediaType]
+	decoderType, ok := bodyDecoderFor(mediaType)
 	if !ok {
 		return nil, &ParseError{
 			Kind:   KindUnsupportedFormat,
 			Reason: fmt.Sprintf("%s %q", prefixUnsupportedCT, mediaType),
 		}
 	}
-	value, err := decoder(body, header, schema, encFn)
+	if charset := mediaTypeCharset(contentType); charset != "" && isTextMediaType(mediaType) {
+		var err error
+		if body, err = charsetReader(charset, body); err != nil {
+			return nil, err
+		}
+	}
+	if decoder, ok := limitedBodyDecoders[decoderType]; ok {
+		return decoder(body, header, schema, encFn, limits)
+	}
+	value, err := bodyDecoders[decoderType](body, header, schema, encFn)
 	if err != nil {
 		return nil, err
 	}
+	if limits.limitsValues() {
+		if err := limits.check(value, 0, nil); err != nil {
+			return nil, err
+		}
+	}
 	return value, nil
 }
 
 func init() {
-	RegisterBodyDecoder("text/plain", plainBodyDecoder)
-	RegisterBodyDecoder("application/json", jsonBodyDecoder)
-	RegisterBodyDecoder("application/x-yaml", yamlBodyDecoder)
-	RegisterBodyDecoder("application/yaml", yamlBodyDecoder)
-	RegisterBodyDecoder("application/problem+json", jsonBodyDecoder)
-	RegisterBodyDecoder("application/x-www-form-urlencoded", urlencodedBodyDecoder)
-	RegisterBodyDecoder("multipart/form-data", multipartBodyDecoder)
-	RegisterBodyDecoder("application/octet-stream", FileBodyDecoder)
+	registerLimitedBodyDecoder("text/plain", plainBodyDecoder)
+	registerLimitedBodyDecoder("application/json", jsonBodyDecoder)
+	registerLimitedBodyDecoder("application/x-yaml", yamlBodyDecoder)
+	registerLimitedBodyDecoder("application/yaml", yamlBodyDecoder)
+	registerLimitedBodyDecoder("application/problem+json", jsonBodyDecoder)
+	registerLimitedBodyDecoder("application/x-www-form-urlencoded", urlencodedBodyDecoder)
+	registerLimitedBodyDecoder("multipart/form-data", multipartBodyDecoder)
+	registerLimitedBodyDecoder("application/octet-stream", fileBodyDecoder)
+	registerLimitedBodyDecoder("application/xml", xmlBodyDecoder)
+	registerLimitedBodyDecoder("text/xml", xmlBodyDecoder)
+	registerLimitedBodyDecoder("application/x-ndjson", ndjsonBodyDecoder)
+	registerLimitedBodyDecoder("text/csv", csvBodyDecoder)
+	registerLimitedBodyDecoder("application/msgpack", msgpackBodyDecoder)
+	registerLimitedBodyDecoder("application/x-msgpack", msgpackBodyDecoder)
+	registerLimitedBodyDecoder("application/vnd.msgpack", msgpackBodyDecoder)
+	registerLimitedBodyDecoder("application/cbor", cborBodyDecoder)
 }
 
-func plainBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+func plainBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
 	data, err := ioutil.ReadAll(body)
 	if err != nil {
 		return nil, &ParseError{Kind: KindInvalidFormat, Cause: err}
 	}
-	return string(data), nil
+	value := string(data)
+	if err := limits.check(value, 0, nil); err != nil {
+		return nil, err
+	}
+	return value, nil
 }
 
-func jsonBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+func jsonBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	dec := json.NewDecoder(body)
 	var value interface{}
-	if err := json.NewDecoder(body).Decode(&value); err != nil {
+	var err error
+	if !limits.limitsValues() {
+		err = dec.Decode(&value)
+	} else {
+		value, err = decodeJSONValue(dec, limits, 0, nil)
+	}
+	if err != nil {
+		if _, ok := err.(*ParseError); ok {
+			return nil, err
+		}
 		return nil, &ParseError{Kind: KindInvalidFormat, Cause: err}
 	}
 	return value, nil
 }
 
-func yamlBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+func yamlBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	data, err := limits.readAll(body)
+	if err != nil {
+		return nil, err
+	}
 	var value interface{}
-	if err := yaml.NewDecoder(body).Decode(&value); err != nil {
+	if err := yaml.Unmarshal(data, &value); err != nil {
 		return nil, &ParseError{Kind: KindInvalidFormat, Cause: err}
 	}
+	if err := limits.check(value, 0, nil); err != nil {
+		return nil, err
+	}
 	return value, nil
 }
 
-func urlencodedBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+func urlencodedBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
 	// Validate schema of request body.
 	// By the OpenAPI 3 specification request body's schema must have type "object".
 	// Properties of the schema describes individual parts of request body.
@@ -955,6 +1048,12 @@ func urlencodedBodyDecoder(body io.Reader, header http.Header, schema *openapi3.
 	}
 
 	// Make an object value from form values.
+	if err = limits.countValue(nil); err == nil {
+		err = limits.checkDepth(1, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
 	obj := make(map[string]interface{})
 	dec := &urlValuesDecoder{values: values}
 	for name, prop := range schema.Value.Properties {
@@ -970,13 +1069,20 @@ func urlencodedBodyDecoder(body io.Reader, header http.Header, schema *openapi3.
 		if value, _, err = decodeValue(dec, name, sm, prop, false); err != nil {
 			return nil, err
 		}
+		path := []interface{}{name}
+		if err = limits.checkString(name, path); err == nil {
+			err = limits.check(value, 1, path)
+		}
+		if err != nil {
+			return nil, err
+		}
 		obj[name] = value
 	}
 
 	return obj, nil
 }
 
-func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
 	if schema.Value.Type != "object" {
 		return nil, errors.New("unsupported schema of request body")
 	}
@@ -988,6 +1094,12 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 	if err != nil {
 		return nil, err
 	}
+	if err = limits.countValue(nil); err == nil {
+		err = limits.checkDepth(1, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
 	mr := multipart.NewReader(body, params["boundary"])
 	for {
 		var part *multipart.Part
@@ -1020,27 +1132,61 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 					continue
 				default:
 					//additionalProperties: false
-					return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
+					return nil, &ParseError{Kind: KindOther, Reason: "undefined part", path: []interface{}{name}}
 				}
 			}
 			if schema.Value.AdditionalProperties == nil {
-				return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
+				return nil, &ParseError{Kind: KindOther, Reason: "undefined part", path: []interface{}{name}}
 			}
 			valueSchema, exists = schema.Value.AdditionalProperties.Value.Properties[name]
 			if !exists {
-				return nil, &ParseError{Kind: KindOther, Cause: fmt.Errorf("part %s: undefined", name)}
+				return nil, &ParseError{Kind: KindOther, Reason: "undefined part", path: []interface{}{name}}
 			}
 		}
 		if valueSchema.Value.Type == "array" {
 			valueSchema = valueSchema.Value.Items
 		}
 
+		if enc != nil {
+			if err = validatePart(name, part, enc); err != nil {
+				return nil, err
+			}
+		}
+		if err = limits.checkString(name, []interface{}{name}); err != nil {
+			return nil, err
+		}
+
 		var value interface{}
-		if value, err = decodeBody(part, http.Header(part.Header), valueSchema, subEncFn); err != nil {
+		if isBinarySchema(valueSchema) {
+			// Binary parts are handed over as readers of their bytes, whatever their content type
+			var data bytes.Buffer
+			if _, err = data.ReadFrom(part); err != nil {
+				return nil, &ParseError{Kind: KindOther, Cause: err, path: []interface{}{name}}
+			}
+			if err = limits.countValue([]interface{}{name}); err != nil {
+				return nil, err
+			}
+			values[name] = append(values[name], BinaryPart{bytes.NewReader(data.Bytes())})
+			continue
+		}
+		// The values of parts are nested in the object of the body
+		if limits != nil {
+			limits.depth++
+		}
+		value, err = decodeBody(part, http.Header(part.Header), valueSchema, subEncFn, limits)
+		if limits != nil {
+			limits.depth--
+		}
+		if err != nil {
 			if v, ok := err.(*ParseError); ok {
 				return nil, &ParseError{path: []interface{}{name}, Cause: v}
 			}
-			return nil, fmt.Errorf("part %s: %s", name, err)
+			return nil, &ParseError{Kind: KindOther, Cause: err, path: []interface{}{name}}
+		}
+		// Parts are decoded as plain text unless they declare their content type,
+		// so convert them to the declared type and let validation report failures.
+		if v, err := valueSchema.Value.CoerceValue(value); err == nil {
+			value = v
 		}
 		values[name] = append(values[name], value)
 	}
@@ -1071,6 +1217,85 @@ func multipartBodyDecoder(body io.Reader, header http.Header, schema *openapi3.S
 	return obj, nil
 }
 
+// isBinarySchema tells whether a schema describes binary data, as strings in format binary do.
+func isBinarySchema(schema *openapi3.SchemaRef) bool {
+	return schema != nil && schema.Value != nil && schema.Value.Type == "string" && schema.Value.Format == "binary"
+}
+
+// BinaryPart is the value of a binary part of a multipart body, such as a file:
+// a reader of a copy of its bytes rather than a string, whose Size is validated
+// against the minLength and maxLength of its schema. The body it is read from
+// is bounded by Options.MaxBodyBytes.
+type BinaryPart struct {
+	*bytes.Reader
+}
+
+// validatePart validates the content type and headers of a part of a multipart body against its encoding.
+// A part without a Content-Type is plain text.
+func validatePart(name string, part *multipart.Part, enc *openapi3.Encoding) error {
+	header := http.Header(part.Header)
+	if enc.ContentType != "" {
+		contentType := header.Get(headerCT)
+		if contentType == "" {
+			contentType = "text/plain"
+		}
+		if !mediaTypeAllowed(contentType, enc.ContentType) {
+			return &ParseError{
+				Kind:   KindUnsupportedFormat,
+				Reason: fmt.Sprintf("%s %q, want %q", prefixUnsupportedCT, parseMediaType(contentType), enc.ContentType),
+				path:   []interface{}{name},
+			}
+		}
+	}
+
+	headerNames := make([]string, 0, len(enc.Headers))
+	for headerName := range enc.Headers {
+		headerNames = append(headerNames, headerName)
+	}
+	sort.Strings(headerNames)
+	for _, headerName := range headerNames {
+		ref := enc.Headers[headerName]
+		// The Content-Type of a part is described by the contentType of its encoding
+		if ref == nil || ref.Value == nil || http.CanonicalHeaderKey(headerName) == headerCT {
+			continue
+		}
+		path := []interface{}{name, headerName}
+		if err := validateHeader(header, headerName, ref.Value); err != nil {
+			if err == ErrInvalidRequired {
+				return &ParseError{Kind: KindOther, Reason: "missing required header", path: path}
+			}
+			return &ParseError{Kind: KindInvalidFormat, Cause: err, path: path}
+		}
+	}
+	return nil
+}
+
+// mediaTypeAllowed tells whether a media type is in a comma-separated list of media types and ranges,
+// such as the contentType of an encoding.
+func mediaTypeAllowed(contentType, allowed string) bool {
+	mediaType, ok := openapi3.ParseMediaRange(contentType)
+	if !ok {
+		return false
+	}
+	for _, s := range strings.Split(allowed, ",") {
+		if mediaRange, ok := openapi3.ParseMediaRange(s); ok && mediaRange.Includes(mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+func fileBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	value, err := FileBodyDecoder(body, header, schema, encFn)
+	if err != nil {
+		return nil, err
+	}
+	if err := limits.check(value, 0, nil); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
 // FileBodyDecoder is a body decoder that decodes a file body to a string.
 func FileBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
 	data, err := ioutil.ReadAll(body)
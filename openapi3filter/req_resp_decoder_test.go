@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -1070,6 +1071,15 @@ func TestDecodeParameter(t *testing.T) {
 	}
 }
 
+func TestParsePrimitiveReason(t *testing.T) {
+	for typ, raw := range map[string]string{"integer": "1.5x", "number": "one", "boolean": "yes"} {
+		_, err := parsePrimitive(raw, openapi3.NewSchemaRef("", &openapi3.Schema{Type: typ}))
+		var parseErr *ParseError
+		require.True(t, errors.As(err, &parseErr))
+		require.Equal(t, "an invalid "+typ, parseErr.Reason)
+	}
+}
+
 func TestDecodeBody(t *testing.T) {
 	boolPtr := func(b bool) *bool { return &b }
 
@@ -1102,6 +1112,15 @@ func TestDecodeBody(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	multipartFormPlainText, multipartFormMimePlainText, err := newTestMultipartForm([]*testFormPart{
+		{name: "b", contentType: "text/plain", data: strings.NewReader("10")},
+		{name: "c", contentType: "text/plain", data: strings.NewReader("1.5")},
+		{name: "c", contentType: "text/plain", data: strings.NewReader("2")},
+		{name: "e", data: strings.NewReader("true")},
+		{name: "h", data: strings.NewReader("h1")},
+	})
+	require.NoError(t, err)
+
 	multipartFormExtraPart, multipartFormMimeExtraPart, err := newTestMultipartForm([]*testFormPart{
 		{name: "a", contentType: "text/plain", data: strings.NewReader("a1")},
 		{name: "x", contentType: "text/plain", data: strings.NewReader("x1")},
@@ -1138,7 +1157,7 @@ func TestDecodeBody(t *testing.T) {
 	}{
 		{
 			name:    prefixUnsupportedCT,
-			mime:    "application/xml",
+			mime:    "application/x-protobuf",
 			wantErr: &ParseError{Kind: KindUnsupportedFormat},
 		},
 		{
@@ -1218,7 +1237,18 @@ func TestDecodeBody(t *testing.T) {
 				WithProperty("d", openapi3.NewObjectSchema().WithProperty("d1", openapi3.NewStringSchema())).
 				WithProperty("f", openapi3.NewStringSchema().WithFormat("binary")).
 				WithProperty("g", openapi3.NewStringSchema()),
-			want: map[string]interface{}{"a": "a1", "b": float64(10), "c": []interface{}{"c1", "c2"}, "d": map[string]interface{}{"d1": "d1"}, "f": "foo", "g": "g1"},
+			want: map[string]interface{}{"a": "a1", "b": float64(10), "c": []interface{}{"c1", "c2"}, "d": map[string]interface{}{"d1": "d1"}, "f": BinaryPart{bytes.NewReader([]byte("foo"))}, "g": "g1"},
+		},
+		{
+			name: "multipart plain text parts",
+			mime: multipartFormMimePlainText,
+			body: multipartFormPlainText,
+			schema: openapi3.NewObjectSchema().
+				WithProperty("b", openapi3.NewIntegerSchema()).
+				WithProperty("c", openapi3.NewArraySchema().WithItems(openapi3.NewFloat64Schema())).
+				WithProperty("e", openapi3.NewBoolSchema()).
+				WithProperty("h", openapi3.NewIntegerSchema()),
+			want: map[string]interface{}{"b": float64(10), "c": []interface{}{1.5, float64(2)}, "e": true, "h": "h1"},
 		},
 		{
 			name: "multipartExtraPart",
@@ -1227,7 +1257,7 @@ func TestDecodeBody(t *testing.T) {
 			schema: openapi3.NewObjectSchema().
 				WithProperty("a", openapi3.NewStringSchema()),
 			want:    map[string]interface{}{"a": "a1"},
-			wantErr: &ParseError{Kind: KindOther},
+			wantErr: &ParseError{Kind: KindOther, path: []interface{}{"x"}},
 		},
 		{
 			name: "multipartAnyAdditionalProperties",
@@ -1257,7 +1287,7 @@ func TestDecodeBody(t *testing.T) {
 					WithProperty("x", openapi3.NewStringSchema())).
 				WithProperty("a", openapi3.NewStringSchema()),
 			want:    map[string]interface{}{"a": "a1", "x": "x1"},
-			wantErr: &ParseError{Kind: KindOther},
+			wantErr: &ParseError{Kind: KindOther, path: []interface{}{"y"}},
 		},
 		{
 			name: "file",
@@ -1280,7 +1310,7 @@ func TestDecodeBody(t *testing.T) {
 				}
 				return tc.encoding[name]
 			}
-			got, err := decodeBody(tc.body, h, schemaRef, encFn)
+			got, err := decodeBody(tc.body, h, schemaRef, encFn, nil)
 
 			if tc.wantErr != nil {
 				require.Error(t, err)
@@ -1299,6 +1329,7 @@ type testFormPart struct {
 	contentType string
 	data        io.Reader
 	filename    string
+	header      map[string]string
 }
 
 func newTestMultipartForm(parts []*testFormPart) (io.Reader, string, error) {
@@ -1317,6 +1348,9 @@ func newTestMultipartForm(parts []*testFormPart) (io.Reader, string, error) {
 		h := make(textproto.MIMEHeader)
 		h.Set(headerCT, p.contentType)
 		h.Set("Content-Disposition", disp)
+		for k, v := range p.header {
+			h.Set(k, v)
+		}
 		pw, err := w.CreatePart(h)
 		if err != nil {
 			return nil, "", err
@@ -1328,6 +1362,98 @@ func newTestMultipartForm(parts []*testFormPart) (io.Reader, string, error) {
 	return form, w.FormDataContentType(), nil
 }
 
+func TestDecodeMultipartParts(t *testing.T) {
+	schema := openapi3.NewObjectSchema().
+		WithProperty("image", openapi3.NewStringSchema().WithFormat("binary")).
+		WithProperty("meta", openapi3.NewObjectSchema().WithProperty("title", openapi3.NewStringSchema())).
+		NewRef()
+	encoding := map[string]*openapi3.Encoding{
+		"image": {
+			ContentType: "image/png, image/jpeg",
+			Headers: openapi3.Headers{
+				"X-Width": &openapi3.HeaderRef{Value: &openapi3.Header{Parameter: openapi3.Parameter{
+					Required: true,
+					Schema:   openapi3.NewIntegerSchema().NewRef(),
+				}}},
+			},
+		},
+		"meta": {ContentType: "application/*"},
+	}
+	encFn := func(name string) *openapi3.Encoding { return encoding[name] }
+
+	testCases := []struct {
+		name    string
+		parts   []*testFormPart
+		want    map[string]interface{}
+		wantErr *ParseError
+	}{
+		{
+			name: "valid",
+			parts: []*testFormPart{
+				{name: "image", contentType: "image/png", data: strings.NewReader("\x89PNG"), header: map[string]string{"X-Width": "640"}},
+				{name: "meta", contentType: "application/json", data: strings.NewReader(`{"title":"Rex"}`)},
+			},
+			want: map[string]interface{}{
+				"image": BinaryPart{bytes.NewReader([]byte("\x89PNG"))},
+				"meta":  map[string]interface{}{"title": "Rex"},
+			},
+		},
+		{
+			name: "content type in range",
+			parts: []*testFormPart{
+				{name: "meta", contentType: "application/vnd.acme+json", data: strings.NewReader(`{"title":"Rex"}`)},
+			},
+			want: map[string]interface{}{
+				"meta": map[string]interface{}{"title": "Rex"},
+			},
+		},
+		{
+			name: "content type not allowed",
+			parts: []*testFormPart{
+				{name: "image", contentType: "image/gif", data: strings.NewReader("GIF89a"), header: map[string]string{"X-Width": "640"}},
+			},
+			wantErr: &ParseError{Kind: KindUnsupportedFormat, path: []interface{}{"image"}},
+		},
+		{
+			name: "content type defaults to plain text",
+			parts: []*testFormPart{
+				{name: "meta", data: strings.NewReader(`{"title":"Rex"}`)},
+			},
+			wantErr: &ParseError{Kind: KindUnsupportedFormat, path: []interface{}{"meta"}},
+		},
+		{
+			name: "missing required header",
+			parts: []*testFormPart{
+				{name: "image", contentType: "image/jpeg", data: strings.NewReader("JFIF")},
+			},
+			wantErr: &ParseError{Kind: KindOther, path: []interface{}{"image", "X-Width"}},
+		},
+		{
+			name: "invalid header",
+			parts: []*testFormPart{
+				{name: "image", contentType: "image/jpeg", data: strings.NewReader("JFIF"), header: map[string]string{"X-Width": "wide"}},
+			},
+			wantErr: &ParseError{Kind: KindInvalidFormat, path: []interface{}{"image", "X-Width"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			body, mime, err := newTestMultipartForm(tc.parts)
+			require.NoError(t, err)
+			h := make(http.Header)
+			h.Set(headerCT, mime)
+			got, err := decodeBody(body, h, schema, encFn, nil)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
 func TestRegisterAndUnregisterBodyDecoder(t *testing.T) {
 	var decoder BodyDecoder
 	decoder = func(body io.Reader, h http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (decoded interface{}, err error) {
@@ -1337,7 +1463,7 @@ func TestRegisterAndUnregisterBodyDecoder(t *testing.T) {
 		}
 		return strings.Split(string(data), ","), nil
 	}
-	contentType := "text/csv"
+	contentType := "text/x-comma-separated-list"
 	h := make(http.Header)
 	h.Set(headerCT, contentType)
 
@@ -1350,7 +1476,7 @@ func TestRegisterAndUnregisterBodyDecoder(t *testing.T) {
 	body := strings.NewReader("foo,bar")
 	schema := openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()).NewRef()
 	encFn := func(string) *openapi3.Encoding { return nil }
-	got, err := decodeBody(body, h, schema, encFn)
+	got, err := decodeBody(body, h, schema, encFn, nil)
 
 	require.NoError(t, err)
 	require.Equal(t, []string{"foo", "bar"}, got)
@@ -1360,10 +1486,10 @@ func TestRegisterAndUnregisterBodyDecoder(t *testing.T) {
 	originalDecoder = RegisteredBodyDecoder(contentType)
 	require.Nil(t, originalDecoder)
 
-	_, err = decodeBody(body, h, schema, encFn)
+	_, err = decodeBody(body, h, schema, encFn, nil)
 	require.Equal(t, &ParseError{
 		Kind:   KindUnsupportedFormat,
-		Reason: prefixUnsupportedCT + ` "text/csv"`,
+		Reason: prefixUnsupportedCT + ` "text/x-comma-separated-list"`,
 	}, err)
 }
 
@@ -1390,3 +1516,133 @@ func matchParseError(got, want error) bool {
 	}
 	return true
 }
+
+func TestDecodeBodyMediaTypes(t *testing.T) {
+	schema := openapi3.NewObjectSchema().WithProperty("name", openapi3.NewStringSchema()).NewRef()
+	testCases := []struct {
+		name    string
+		mime    string
+		body    []byte
+		want    interface{}
+		wantErr error
+	}{
+		{
+			name: "json suffix",
+			mime: "application/hal+json",
+			body: []byte(`{"name":"Rex"}`),
+			want: map[string]interface{}{"name": "Rex"},
+		},
+		{
+			name: "json suffix with parameters",
+			mime: "application/vnd.acme.v2+json; version=2",
+			body: []byte(`{"name":"Rex"}`),
+			want: map[string]interface{}{"name": "Rex"},
+		},
+		{
+			name: "upper case json suffix",
+			mime: "Application/Merge-Patch+JSON",
+			body: []byte(`{"name":"Rex"}`),
+			want: map[string]interface{}{"name": "Rex"},
+		},
+		{
+			name: "xml suffix",
+			mime: "application/soap+xml",
+			body: []byte(`<pet><name>Rex</name></pet>`),
+			want: map[string]interface{}{"name": "Rex"},
+		},
+		{
+			name: "latin1 charset",
+			mime: "application/json; charset=ISO-8859-1",
+			body: []byte("{\"name\":\"Ren\xe9\"}"),
+			want: map[string]interface{}{"name": "René"},
+		},
+		{
+			name: "utf-16 charset with byte order mark",
+			mime: "application/json; charset=utf-16",
+			body: []byte("\xff\xfe{\x00\"\x00n\x00a\x00m\x00e\x00\"\x00:\x00\"\x00\xe9\x00\"\x00}\x00"),
+			want: map[string]interface{}{"name": "é"},
+		},
+		{
+			name: "utf-8 charset",
+			mime: "application/json; charset=UTF-8",
+			body: []byte(`{"name":"René"}`),
+			want: map[string]interface{}{"name": "René"},
+		},
+		{
+			name: "xml charset of declaration",
+			mime: "application/xml",
+			body: []byte("<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><pet><name>Ren\xe9</name></pet>"),
+			want: map[string]interface{}{"name": "René"},
+		},
+		{
+			name: "charset without decoder",
+			mime: "application/json; charset=koi8-r",
+			body: []byte(`{"name":"Rex"}`),
+			want: map[string]interface{}{"name": "Rex"},
+		},
+		{
+			name:    "unknown suffix",
+			mime:    "application/vnd.acme+protobuf",
+			body:    []byte(`{"name":"Rex"}`),
+			wantErr: &ParseError{Kind: KindUnsupportedFormat},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header)
+			h.Set(headerCT, tc.mime)
+			got, err := decodeBody(bytes.NewReader(tc.body), h, schema, nil, nil)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegisterBodyDecoderForRange(t *testing.T) {
+	decoder := func(body io.Reader, h http.Header, schema *openapi3.SchemaRef, encFn EncodingFn) (interface{}, error) {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		return string(data), nil
+	}
+	RegisterBodyDecoder("text/*", decoder)
+	defer UnregisterBodyDecoder("text/*")
+
+	schema := openapi3.NewStringSchema().NewRef()
+	h := make(http.Header)
+	h.Set(headerCT, "text/markdown")
+	got, err := decodeBody(strings.NewReader("# Title"), h, schema, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "# Title", got)
+
+	h.Set(headerCT, "text/x-custom; charset=latin1")
+	got, err = decodeBody(strings.NewReader("caf\xe9"), h, schema, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "café", got)
+
+	// The decoders of the media types in the range are kept
+	h.Set(headerCT, "text/plain")
+	got, err = decodeBody(strings.NewReader("plain"), h, schema, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "plain", got)
+
+	h.Set(headerCT, "image/png")
+	_, err = decodeBody(strings.NewReader("png"), h, schema, nil, nil)
+	require.Error(t, err)
+}
+
+func TestDecodeBodyCharsetOfBinaryContent(t *testing.T) {
+	schema := openapi3.NewStringSchema().WithFormat("binary").NewRef()
+	h := make(http.Header)
+	// An odd number of bytes is invalid UTF-16 text
+	h.Set(headerCT, "application/octet-stream; charset=utf-16")
+	got, err := decodeBody(strings.NewReader("\xe9"), h, schema, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, "\xe9", got)
+}
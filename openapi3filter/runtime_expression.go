@@ -0,0 +1,226 @@
+package openapi3filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// RuntimeExpressionInput is the request and, once there is one, the response
+// that runtime expressions, such as "$request.body#/id" or "$response.header.Location", are evaluated over.
+type RuntimeExpressionInput struct {
+	Request *http.Request
+	// PathParams are the values of the path parameters of the request, as routers find them.
+	PathParams map[string]string
+	// RequestBody is the body of the request. The body of Request is not read.
+	RequestBody []byte
+
+	Status         int
+	ResponseHeader http.Header
+	ResponseBody   []byte
+}
+
+// Evaluate returns the value of a runtime expression: a string for $url, $method,
+// headers, query and path parameters; a float64 for $statusCode; and the JSON value
+// a body or the JSON pointer into it gives. A body which is not JSON is a string.
+// Values that the request or the response do not have are nil, while JSON pointers
+// which do not resolve in a body are errors.
+func (input *RuntimeExpressionInput) Evaluate(expression string) (interface{}, error) {
+	switch expression {
+	case "$url":
+		if input.Request == nil {
+			return nil, nil
+		}
+		return requestURL(input.Request), nil
+	case "$method":
+		if input.Request == nil {
+			return nil, nil
+		}
+		return input.Request.Method, nil
+	case "$statusCode":
+		if input.Status == 0 {
+			return nil, nil
+		}
+		return float64(input.Status), nil
+	}
+
+	var source, rest string
+	switch {
+	case strings.HasPrefix(expression, "$request."):
+		source, rest = "request", strings.TrimPrefix(expression, "$request.")
+	case strings.HasPrefix(expression, "$response."):
+		source, rest = "response", strings.TrimPrefix(expression, "$response.")
+	default:
+		return nil, fmt.Errorf("invalid runtime expression %q", expression)
+	}
+
+	if rest == "body" || strings.HasPrefix(rest, "body#") {
+		body := input.RequestBody
+		if source == "response" {
+			body = input.ResponseBody
+		}
+		return bodyValue(body, strings.TrimPrefix(strings.TrimPrefix(rest, "body"), "#"))
+	}
+
+	i := strings.IndexByte(rest, '.')
+	if i < 0 || i == len(rest)-1 {
+		return nil, fmt.Errorf("invalid runtime expression %q", expression)
+	}
+	kind, name := rest[:i], rest[i+1:]
+	switch {
+	case kind == "header" && source == "request":
+		if input.Request == nil {
+			return nil, nil
+		}
+		return headerValue(input.Request.Header, name), nil
+	case kind == "header" && source == "response":
+		return headerValue(input.ResponseHeader, name), nil
+	case kind == "query" && source == "request":
+		if input.Request == nil {
+			return nil, nil
+		}
+		if values, ok := input.Request.URL.Query()[name]; ok {
+			return values[0], nil
+		}
+		return nil, nil
+	case kind == "path" && source == "request":
+		if value, ok := input.PathParams[name]; ok {
+			return value, nil
+		}
+		return nil, nil
+	}
+	return nil, fmt.Errorf("invalid runtime expression %q", expression)
+}
+
+// Expand returns a string, such as a callback URL, with the runtime expressions
+// in braces in it replaced with their values. Values that are not strings are written as JSON,
+// and values that the request or the response do not have are empty.
+func (input *RuntimeExpressionInput) Expand(s string) (string, error) {
+	var buf strings.Builder
+	for {
+		start := strings.Index(s, "{$")
+		if start < 0 {
+			buf.WriteString(s)
+			return buf.String(), nil
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated runtime expression in %q", s)
+		}
+		end += start
+		value, err := input.Evaluate(s[start+1 : end])
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(s[:start])
+		switch v := value.(type) {
+		case nil:
+		case string:
+			buf.WriteString(v)
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			buf.Write(data)
+		}
+		s = s[end+1:]
+	}
+}
+
+// LinkParameters returns the values of the parameters of a link, by the names the link gives them,
+// to call the operation of the link with. Values are runtime expressions, strings with
+// runtime expressions in braces, or constants.
+func (input *RuntimeExpressionInput) LinkParameters(link *openapi3.Link) (map[string]interface{}, error) {
+	names := make([]string, 0, len(link.Parameters))
+	for name := range link.Parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		value, err := input.linkValue(link.Parameters[name])
+		if err != nil {
+			return nil, fmt.Errorf("link parameter %q: %v", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// LinkRequestBody returns the request body of a link to call its operation with, if it has one.
+func (input *RuntimeExpressionInput) LinkRequestBody(link *openapi3.Link) (interface{}, error) {
+	value, err := input.linkValue(link.RequestBody)
+	if err != nil {
+		return nil, fmt.Errorf("link request body: %v", err)
+	}
+	return value, nil
+}
+
+func (input *RuntimeExpressionInput) linkValue(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	switch {
+	case !ok:
+		return value, nil
+	case strings.HasPrefix(s, "$"):
+		return input.Evaluate(s)
+	case strings.Contains(s, "{$"):
+		return input.Expand(s)
+	}
+	return s, nil
+}
+
+// requestURL returns the absolute URL of a request, which for requests
+// a server receives has its scheme and host from the connection.
+func requestURL(req *http.Request) string {
+	if req.URL.IsAbs() {
+		return req.URL.String()
+	}
+	u := *req.URL
+	u.Scheme, u.Host = "http", req.Host
+	if req.TLS != nil {
+		u.Scheme = "https"
+	}
+	return u.String()
+}
+
+func headerValue(header http.Header, name string) interface{} {
+	if values := header.Values(name); len(values) != 0 {
+		return values[0]
+	}
+	return nil
+}
+
+// bodyValue returns the JSON value a JSON pointer gives in a body,
+// or the whole body without one.
+func bodyValue(body []byte, pointer string) (interface{}, error) {
+	if body == nil {
+		return nil, nil
+	}
+	var doc interface{}
+	if err := json.Unmarshal(body, &doc); err != nil {
+		if pointer == "" {
+			return string(body), nil
+		}
+		return nil, fmt.Errorf("body is not JSON: %v", err)
+	}
+	if pointer == "" {
+		return doc, nil
+	}
+	p, err := jsonpointer.New(pointer)
+	if err != nil {
+		return nil, err
+	}
+	value, _, err := p.Get(doc)
+	if err != nil {
+		return nil, fmt.Errorf("JSON pointer %q does not resolve in body: %v", pointer, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,135 @@
+package openapi3filter
+
+import (
+	"crypto/tls"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func newTestRuntimeExpressionInput(t *testing.T) *RuntimeExpressionInput {
+	req, err := http.NewRequest(http.MethodPost, "/users/42/orders?cb=http%3A%2F%2Fexample.com%2Fhook&x=1", nil)
+	require.NoError(t, err)
+	req.Host = "api.example.com"
+	req.Header.Set("X-Request-Id", "abc")
+	return &RuntimeExpressionInput{
+		Request:        req,
+		PathParams:     map[string]string{"userId": "42"},
+		RequestBody:    []byte(`{"id": 7, "items": [{"sku": "a/b"}], "a/b": true}`),
+		Status:         http.StatusCreated,
+		ResponseHeader: http.Header{"Location": {"/orders/7"}},
+		ResponseBody:   []byte(`{"orderId": "o-7", "total": 9.5}`),
+	}
+}
+
+func TestRuntimeExpressionEvaluate(t *testing.T) {
+	input := newTestRuntimeExpressionInput(t)
+
+	testCases := []struct {
+		expression string
+		want       interface{}
+		wantErr    bool
+	}{
+		{expression: "$url", want: "http://api.example.com/users/42/orders?cb=http%3A%2F%2Fexample.com%2Fhook&x=1"},
+		{expression: "$method", want: "POST"},
+		{expression: "$statusCode", want: 201.0},
+		{expression: "$request.path.userId", want: "42"},
+		{expression: "$request.path.orderId", want: nil},
+		{expression: "$request.query.cb", want: "http://example.com/hook"},
+		{expression: "$request.header.x-request-id", want: "abc"},
+		{expression: "$request.body#/id", want: 7.0},
+		{expression: "$request.body#/items/0/sku", want: "a/b"},
+		{expression: "$request.body#/a~1b", want: true},
+		{expression: "$request.body#/missing", wantErr: true},
+		{expression: "$response.header.Location", want: "/orders/7"},
+		{expression: "$response.body#/orderId", want: "o-7"},
+		{expression: "$response.body", want: map[string]interface{}{"orderId": "o-7", "total": 9.5}},
+		{expression: "$request.cookie.id", wantErr: true},
+		{expression: "$response.query.x", wantErr: true},
+		{expression: "$request.header.", wantErr: true},
+		{expression: "$request.body#id", wantErr: true},
+		{expression: "request.body", wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.expression, func(t *testing.T) {
+			got, err := input.Evaluate(tc.expression)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	input.Request.TLS = &tls.ConnectionState{}
+	got, err := input.Evaluate("$url")
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com/users/42/orders?cb=http%3A%2F%2Fexample.com%2Fhook&x=1", got)
+
+	input.ResponseBody = []byte("created")
+	got, err = input.Evaluate("$response.body")
+	require.NoError(t, err)
+	require.Equal(t, "created", got)
+	_, err = input.Evaluate("$response.body#/orderId")
+	require.Error(t, err)
+}
+
+func TestRuntimeExpressionExpand(t *testing.T) {
+	input := newTestRuntimeExpressionInput(t)
+
+	got, err := input.Expand("{$request.query.cb}?order={$response.body#/orderId}&total={$response.body#/total}&none={$request.header.X-None}")
+	require.NoError(t, err)
+	require.Equal(t, "http://example.com/hook?order=o-7&total=9.5&none=", got)
+
+	got, err = input.Expand("{literal}/{$method}")
+	require.NoError(t, err)
+	require.Equal(t, "{literal}/POST", got)
+
+	_, err = input.Expand("{$request.query.cb")
+	require.Error(t, err)
+	_, err = input.Expand("{$request.nothing}")
+	require.Error(t, err)
+	_, err = input.Expand("{$request.body#/callbakUrl}/data")
+	require.EqualError(t, err, `JSON pointer "/callbakUrl" does not resolve in body: object has no key "callbakUrl"`)
+}
+
+func TestRuntimeExpressionLink(t *testing.T) {
+	input := newTestRuntimeExpressionInput(t)
+
+	link := &openapi3.Link{
+		OperationID: "getOrder",
+		Parameters: map[string]interface{}{
+			"orderId":   "$response.body#/orderId",
+			"path.user": "$request.path.userId",
+			"href":      "https://api.example.com{$response.header.Location}",
+			"limit":     10.0,
+			"format":    "json",
+		},
+		RequestBody: "$request.body#/items",
+	}
+	params, err := input.LinkParameters(link)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{
+		"orderId":   "o-7",
+		"path.user": "42",
+		"href":      "https://api.example.com/orders/7",
+		"limit":     10.0,
+		"format":    "json",
+	}, params)
+
+	body, err := input.LinkRequestBody(link)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{map[string]interface{}{"sku": "a/b"}}, body)
+
+	body, err = input.LinkRequestBody(&openapi3.Link{OperationID: "getOrder"})
+	require.NoError(t, err)
+	require.Nil(t, body)
+
+	link.Parameters["bad"] = "$request.nothing"
+	_, err = input.LinkParameters(link)
+	require.EqualError(t, err, `link parameter "bad": invalid runtime expression "$request.nothing"`)
+}
@@ -0,0 +1,112 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// CallbackURLs returns the URLs of a callback, by its expressions, such as
+// "{$request.body#/callbackUrl}/data", evaluated over the request that registered the callback.
+func CallbackURLs(input *RuntimeExpressionInput, callback openapi3.Callback) (map[string]string, error) {
+	urls := make(map[string]string, len(callback))
+	for _, expression := range callbackExpressions(callback) {
+		u, err := input.Expand(expression)
+		if err != nil {
+			return nil, fmt.Errorf("callback %q: %v", expression, err)
+		}
+		urls[expression] = u
+	}
+	return urls, nil
+}
+
+// ValidateCallbackRequest validates an outbound callback request against the path item
+// of the expression of the callback whose URL it is sent to. The URLs of the callback are
+// evaluated over the request that registered the callback.
+//
+// Note: One can tune the behavior of the validation with input.Options.
+func ValidateCallbackRequest(ctx context.Context, input *CallbackValidationInput) error {
+	if input.Route == nil || input.Route.Spec == nil {
+		return errors.New("callback validation requires the route of the operation that registered the callback")
+	}
+	urls, err := CallbackURLs(input.Registration, input.Callback)
+	if err != nil {
+		return err
+	}
+	req := input.Request
+	for _, expression := range callbackExpressions(input.Callback) {
+		if !callbackURLMatches(urls[expression], req.URL) {
+			continue
+		}
+		pathItem := input.Callback[expression]
+		operation := pathItem.GetOperation(req.Method)
+		if operation == nil {
+			return routers.ErrMethodNotAllowed
+		}
+		return ValidateRequest(ctx, &RequestValidationInput{
+			Request: req,
+			Route: &routers.Route{
+				Spec:      input.Route.Spec,
+				Path:      expression,
+				PathItem:  pathItem,
+				Method:    req.Method,
+				Operation: operation,
+			},
+			Options: input.Options,
+		})
+	}
+	return routers.ErrPathNotFound
+}
+
+func callbackExpressions(callback openapi3.Callback) []string {
+	expressions := make([]string, 0, len(callback))
+	for expression, pathItem := range callback {
+		if pathItem != nil {
+			expressions = append(expressions, expression)
+		}
+	}
+	sort.Strings(expressions)
+	return expressions
+}
+
+// callbackURLMatches tells whether a request is sent to a callback URL: its scheme,
+// host and path are the ones of the URL, and its query has the parameters of the URL.
+func callbackURLMatches(callbackURL string, u *url.URL) bool {
+	want, err := url.Parse(callbackURL)
+	if err != nil {
+		return false
+	}
+	if want.Scheme != "" && !strings.EqualFold(want.Scheme, u.Scheme) {
+		return false
+	}
+	if want.Host != "" && !strings.EqualFold(want.Host, u.Host) {
+		return false
+	}
+	if strings.TrimSuffix(want.Path, "/") != strings.TrimSuffix(u.Path, "/") {
+		return false
+	}
+	query := u.Query()
+	for name, values := range want.Query() {
+		for _, value := range values {
+			if !stringInSlice(value, query[name]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func stringInSlice(s string, values []string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
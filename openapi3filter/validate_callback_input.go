@@ -0,0 +1,20 @@
+package openapi3filter
+
+import (
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// CallbackValidationInput is an outbound callback request, with the request that registered the callback.
+type CallbackValidationInput struct {
+	Request *http.Request
+	// Registration is the request that registered the callback, which the URLs of the callback are evaluated over.
+	Registration *RuntimeExpressionInput
+	// Route is the route of the operation that registered the callback. Its Spec declares
+	// the security schemes of the callback's operations.
+	Route    *routers.Route
+	Callback openapi3.Callback
+	Options  *Options
+}
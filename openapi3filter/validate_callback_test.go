@@ -0,0 +1,179 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+const callbackSpec = `
+openapi: 3.0.0
+info: {title: Subscriptions, version: 1.0.0}
+paths:
+  /subscriptions:
+    post:
+      parameters:
+        - {name: cb, in: query, required: true, schema: {type: string}}
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                id: {type: string}
+      responses:
+        "201": {description: Created}
+      callbacks:
+        onEvent:
+          "{$request.query.cb}/events?subscription={$request.body#/id}":
+            post:
+              parameters:
+                - {name: X-Event, in: header, required: true, schema: {type: string, enum: [created, deleted]}}
+              requestBody:
+                required: true
+                content:
+                  application/json:
+                    schema:
+                      type: object
+                      required: [eventId]
+                      properties:
+                        eventId: {type: integer}
+              responses:
+                "200": {description: OK}
+        onCancel:
+          "{$request.query.cb}/cancellations":
+            post:
+              security:
+                - signature: []
+              responses:
+                "200": {description: OK}
+components:
+  securitySchemes:
+    signature: {type: apiKey, in: header, name: X-Signature}
+`
+
+func TestValidateCallbackRequest(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(callbackSpec))
+	require.NoError(t, err)
+	err = doc.Validate(loader.Context)
+	require.NoError(t, err)
+	operation := doc.Paths["/subscriptions"].Post
+	route := &routers.Route{
+		Spec:      doc,
+		Path:      "/subscriptions",
+		PathItem:  doc.Paths["/subscriptions"],
+		Method:    http.MethodPost,
+		Operation: operation,
+	}
+	callback := *operation.Callbacks["onEvent"].Value
+
+	subscribe, err := http.NewRequest(http.MethodPost, "/subscriptions?cb=https%3A%2F%2Fhooks.example.com%2Fv1", nil)
+	require.NoError(t, err)
+	input := &RuntimeExpressionInput{
+		Request:     subscribe,
+		RequestBody: []byte(`{"id": "s1"}`),
+	}
+
+	urls, err := CallbackURLs(input, callback)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"{$request.query.cb}/events?subscription={$request.body#/id}": "https://hooks.example.com/v1/events?subscription=s1",
+	}, urls)
+
+	validate := func(method, url, event, body string) error {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(headerCT, "application/json")
+		if event != "" {
+			req.Header.Set("X-Event", event)
+		}
+		return ValidateCallbackRequest(context.Background(), &CallbackValidationInput{
+			Request:      req,
+			Registration: input,
+			Route:        route,
+			Callback:     callback,
+		})
+	}
+
+	err = validate(http.MethodPost, "https://hooks.example.com/v1/events?subscription=s1", "created", `{"eventId": 1}`)
+	require.NoError(t, err)
+
+	err = validate(http.MethodPost, "https://hooks.example.com/v1/events?subscription=s1&retry=2", "deleted", `{"eventId": 2}`)
+	require.NoError(t, err)
+
+	err = validate(http.MethodPost, "https://hooks.example.com/v1/events?subscription=s1", "updated", `{"eventId": 1}`)
+	var reqErr *RequestError
+	require.True(t, errors.As(err, &reqErr))
+	require.Equal(t, "X-Event", reqErr.Parameter.Name)
+
+	err = validate(http.MethodPost, "https://hooks.example.com/v1/events?subscription=s1", "created", `{"eventId": "one"}`)
+	require.True(t, errors.As(err, &reqErr))
+	require.NotNil(t, reqErr.RequestBody)
+
+	err = validate(http.MethodPost, "https://hooks.example.com/v1/events?subscription=s2", "created", `{"eventId": 1}`)
+	require.Equal(t, routers.ErrPathNotFound, err)
+
+	err = validate(http.MethodPost, "https://other.example.com/v1/events?subscription=s1", "created", `{"eventId": 1}`)
+	require.Equal(t, routers.ErrPathNotFound, err)
+
+	err = validate(http.MethodPut, "https://hooks.example.com/v1/events?subscription=s1", "created", `{"eventId": 1}`)
+	require.Equal(t, routers.ErrMethodNotAllowed, err)
+}
+
+func TestValidateCallbackRequestSecurity(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(callbackSpec))
+	require.NoError(t, err)
+	err = doc.Validate(loader.Context)
+	require.NoError(t, err)
+	operation := doc.Paths["/subscriptions"].Post
+	route := &routers.Route{
+		Spec:      doc,
+		Path:      "/subscriptions",
+		PathItem:  doc.Paths["/subscriptions"],
+		Method:    http.MethodPost,
+		Operation: operation,
+	}
+
+	subscribe, err := http.NewRequest(http.MethodPost, "/subscriptions?cb=https%3A%2F%2Fhooks.example.com%2Fv1", nil)
+	require.NoError(t, err)
+	options := &Options{
+		AuthenticationFunc: func(ctx context.Context, input *AuthenticationInput) error {
+			require.Equal(t, "signature", input.SecuritySchemeName)
+			if input.RequestValidationInput.Request.Header.Get(input.SecurityScheme.Name) == "" {
+				return errors.New("missing signature")
+			}
+			return nil
+		},
+	}
+	validate := func(signature string) error {
+		req, err := http.NewRequest(http.MethodPost, "https://hooks.example.com/v1/cancellations", nil)
+		require.NoError(t, err)
+		if signature != "" {
+			req.Header.Set("X-Signature", signature)
+		}
+		return ValidateCallbackRequest(context.Background(), &CallbackValidationInput{
+			Request:      req,
+			Registration: &RuntimeExpressionInput{Request: subscribe},
+			Route:        route,
+			Callback:     *operation.Callbacks["onCancel"].Value,
+			Options:      options,
+		})
+	}
+
+	err = validate("s3cr3t")
+	require.NoError(t, err)
+
+	err = validate("")
+	var securityErr *SecurityRequirementsError
+	require.True(t, errors.As(err, &securityErr))
+	require.EqualError(t, securityErr.Errors[0], "missing signature")
+}
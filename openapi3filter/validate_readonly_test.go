@@ -90,3 +90,82 @@ func TestValidatingRequestBodyWithReadOnlyProperty(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestValidatingReadOnlyWriteOnlyProperties(t *testing.T) {
+	const spec = `
+openapi: 3.0.3
+info:
+  version: 1.0.0
+  title: title
+paths:
+  /accounts:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Account'
+      responses:
+        '201':
+          description: Successfully created a new account
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Account'
+components:
+  schemas:
+    Account:
+      type: object
+      required: [_id, password]
+      properties:
+        _id:
+          type: string
+          readOnly: true
+        password:
+          type: string
+          writeOnly: true
+`
+
+	sl := openapi3.NewLoader()
+	doc, err := sl.LoadFromData([]byte(spec))
+	require.NoError(t, err)
+	err = doc.Validate(sl.Context)
+	require.NoError(t, err)
+	router, err := legacyrouter.NewRouter(doc)
+	require.NoError(t, err)
+
+	newInput := func(body string, options *Options) *RequestValidationInput {
+		httpReq, err := http.NewRequest(http.MethodPost, "/accounts", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		httpReq.Header.Add(headerCT, "application/json")
+		route, pathParams, err := router.FindRoute(httpReq)
+		require.NoError(t, err)
+		return &RequestValidationInput{
+			Request:    httpReq,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+	}
+
+	err = ValidateRequest(sl.Context, newInput(`{"password":"secret"}`, nil))
+	require.NoError(t, err)
+	err = ValidateRequest(sl.Context, newInput(`{"_id":"bt6kdc3d0cvp6u8u3ft0","password":"secret"}`, nil))
+	require.NoError(t, err)
+	err = ValidateRequest(sl.Context, newInput(`{"_id":"bt6kdc3d0cvp6u8u3ft0","password":"secret"}`, &Options{RejectReadOnly: true}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `readOnly property "_id" in request`)
+
+	validateResponse := func(body string) error {
+		return ValidateResponse(sl.Context, (&ResponseValidationInput{
+			RequestValidationInput: newInput(`{"password":"secret"}`, nil),
+			Status:                 http.StatusCreated,
+			Header:                 http.Header{headerCT: []string{"application/json"}},
+		}).SetBodyBytes([]byte(body)))
+	}
+	require.NoError(t, validateResponse(`{"_id":"bt6kdc3d0cvp6u8u3ft0"}`))
+	err = validateResponse(`{"_id":"bt6kdc3d0cvp6u8u3ft0","password":"secret"}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `writeOnly property "password" in response`)
+}
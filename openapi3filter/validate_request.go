@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"sort"
+	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -22,6 +23,9 @@ var ErrInvalidRequired = errors.New("value is required but missing")
 // ErrInvalidEmptyValue is returned when a value of a parameter or request body is empty while it's not allowed.
 var ErrInvalidEmptyValue = errors.New("empty value is not allowed")
 
+// ErrNotAcceptable is returned when the media types of a response are not acceptable to a request's Accept header.
+var ErrNotAcceptable = errors.New("media type is not acceptable")
+
 // ValidateRequest is used to validate the given input according to previous
 // loaded OpenAPIv3 spec. If the input does not match the OpenAPIv3 spec, a
 // non-nil error will be returned.
@@ -89,6 +93,17 @@ func ValidateRequest(ctx context.Context, input *RequestValidationInput) error {
 		}
 	}
 
+	// Accept
+	if options.RejectNotAcceptable {
+		if err = validateAccept(input); err != nil && !options.MultiError {
+			return err
+		}
+
+		if err != nil {
+			me = append(me, err)
+		}
+	}
+
 	// RequestBody
 	requestBody := operation.RequestBody
 	if requestBody != nil && !options.ExcludeRequestBody {
@@ -158,17 +173,50 @@ func ValidateParameter(ctx context.Context, input *RequestValidationInput, param
 		return nil
 	}
 
-	var opts []openapi3.SchemaValidationOption
+	opts := make([]openapi3.SchemaValidationOption, 0, 5) // 5 potential opts here
+	opts = append(opts, openapi3.VisitAsRequest())
+	if options.RejectReadOnly {
+		opts = append(opts, openapi3.RejectReadOnly())
+	}
+	if options.RejectUnknownProperties {
+		opts = append(opts, openapi3.RejectUnknownProperties())
+	}
 	if options.MultiError {
-		opts = make([]openapi3.SchemaValidationOption, 0, 1)
 		opts = append(opts, openapi3.MultiErrors())
 	}
+	if parameter.Schema != nil && parameter.Schema.Ref != "" {
+		opts = append(opts, openapi3.SchemaLocation(parameter.Schema.Ref))
+	}
 	if err = schema.VisitJSON(value, opts...); err != nil {
 		return &RequestError{Input: input, Parameter: parameter, Err: err}
 	}
 	return nil
 }
 
+// validateAccept validates that a request's Accept header accepts a media type
+// of the successful responses of its operation.
+func validateAccept(input *RequestValidationInput) error {
+	accept := input.Request.Header.Values("Accept")
+	ranges := parseAccept(accept)
+	if len(ranges) == 0 {
+		return nil
+	}
+	mediaTypes, ok := successContentTypes(input.Route.Operation.Responses)
+	if !ok || len(mediaTypes) == 0 {
+		return nil
+	}
+	for _, mediaType := range mediaTypes {
+		if acceptQuality(ranges, mediaType) > 0 {
+			return nil
+		}
+	}
+	return &RequestError{
+		Input:  input,
+		Reason: fmt.Sprintf("header Accept %q accepts none of the media types of the responses", strings.Join(accept, ", ")),
+		Err:    ErrNotAcceptable,
+	}
+}
+
 const prefixInvalidCT = "header Content-Type has unexpected value"
 
 // ValidateRequestBody validates data of a request's body.
@@ -188,8 +236,16 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 
 	if req.Body != http.NoBody && req.Body != nil {
 		defer req.Body.Close()
+		if max := options.MaxBodyBytes; max > 0 && req.ContentLength > max {
+			return &RequestError{
+				Input:       input,
+				RequestBody: requestBody,
+				Reason:      "reading failed",
+				Err:         newBodyTooLargeError(max),
+			}
+		}
 		var err error
-		if data, err = ioutil.ReadAll(req.Body); err != nil {
+		if data, err = readBody(req.Body, options.MaxBodyBytes); err != nil {
 			return &RequestError{
 				Input:       input,
 				RequestBody: requestBody,
@@ -230,7 +286,7 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 	}
 
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
-	value, err := decodeBody(bytes.NewReader(data), req.Header, contentType.Schema, encFn)
+	value, err := decodeBody(bytes.NewReader(data), req.Header, contentType.Schema, encFn, newBodyLimits(options))
 	if err != nil {
 		return &RequestError{
 			Input:       input,
@@ -240,11 +296,20 @@ func ValidateRequestBody(ctx context.Context, input *RequestValidationInput, req
 		}
 	}
 
-	opts := make([]openapi3.SchemaValidationOption, 0, 2) // 2 potential opts here
+	opts := make([]openapi3.SchemaValidationOption, 0, 5) // 5 potential opts here
 	opts = append(opts, openapi3.VisitAsRequest())
+	if options.RejectReadOnly {
+		opts = append(opts, openapi3.RejectReadOnly())
+	}
+	if options.RejectUnknownProperties {
+		opts = append(opts, openapi3.RejectUnknownProperties())
+	}
 	if options.MultiError {
 		opts = append(opts, openapi3.MultiErrors())
 	}
+	if ref := contentType.Schema.Ref; ref != "" {
+		opts = append(opts, openapi3.SchemaLocation(ref))
+	}
 
 	// Validate JSON with the schema
 	if err := contentType.Schema.Value.VisitJSON(value, opts...); err != nil {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -182,3 +183,233 @@ components:
 		})
 	}
 }
+
+func TestValidateRequestBodySchemaLocation(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+          maxLength: 5
+`
+
+	router := setupTestRouter(t, spec)
+
+	req, err := http.NewRequest(http.MethodPost, "/pets", bytes.NewBufferString(`{"name":"Snoopy"}`))
+	require.NoError(t, err)
+	req.Header.Add("Content-Type", "application/json")
+
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	err = ValidateRequest(context.Background(), &RequestValidationInput{
+		Request:    req,
+		PathParams: pathParams,
+		Route:      route,
+	})
+	require.Error(t, err)
+
+	requestErr, ok := err.(*RequestError)
+	require.True(t, ok)
+	schemaErr, ok := requestErr.Err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/name", schemaErr.InstanceLocation())
+	require.Equal(t, "/properties/name/maxLength", schemaErr.KeywordLocation())
+	require.Equal(t, "#/components/schemas/Pet/properties/name/maxLength", schemaErr.AbsoluteKeywordLocation())
+	require.Equal(t, "Pet", schemaErr.ComponentName())
+}
+
+func TestValidateRequestBodyRejectUnknownProperties(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              allOf:
+                - $ref: '#/components/schemas/Named'
+                - type: object
+                  properties:
+                    age:
+                      type: integer
+      responses:
+        '201':
+          description: Created
+components:
+  schemas:
+    Named:
+      type: object
+      properties:
+        name:
+          type: string
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(body string, options *Options) error {
+		req, err := http.NewRequest(http.MethodPost, "/pets", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", "application/json")
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		return ValidateRequest(context.Background(), &RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		})
+	}
+
+	require.NoError(t, validate(`{"name":"Rex","agee":3}`, nil))
+	require.NoError(t, validate(`{"name":"Rex","age":3}`, &Options{RejectUnknownProperties: true}))
+	err := validate(`{"name":"Rex","agee":3}`, &Options{RejectUnknownProperties: true})
+	require.Error(t, err)
+	requestErr, ok := err.(*RequestError)
+	require.True(t, ok)
+	schemaErr, ok := requestErr.Err.(*openapi3.SchemaError)
+	require.True(t, ok)
+	require.Equal(t, "/agee", schemaErr.InstanceLocation())
+}
+
+func TestValidateRequestBodyMediaTypeParameters(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/vnd.pets+json; version=1:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+          application/vnd.pets+json; version=2:
+            schema:
+              type: object
+              required: [names]
+              properties:
+                names:
+                  type: array
+                  items:
+                    type: string
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(contentType, body string) error {
+		req, err := http.NewRequest(http.MethodPost, "/pets", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Add("Content-Type", contentType)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		return ValidateRequest(context.Background(), &RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		})
+	}
+
+	require.NoError(t, validate("application/vnd.pets+json; version=1", `{"name":"Rex"}`))
+	require.NoError(t, validate("application/vnd.pets+json; version=2; charset=utf-8", `{"names":["Rex"]}`))
+	require.Error(t, validate("application/vnd.pets+json; version=2", `{"name":"Rex"}`))
+	require.Error(t, validate("application/vnd.pets+json", `{"name":"Rex"}`))
+}
+
+func TestValidateRequestBodyMultipartParts(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /avatars:
+    post:
+      requestBody:
+        required: true
+        content:
+          multipart/form-data:
+            schema:
+              type: object
+              required: [avatar]
+              properties:
+                avatar:
+                  type: string
+                  format: binary
+                  maxLength: 8
+            encoding:
+              avatar:
+                contentType: image/*
+      responses:
+        '201':
+          description: Created
+`
+
+	router := setupTestRouter(t, spec)
+
+	validate := func(contentType, data string) error {
+		body, mime, err := newTestMultipartForm([]*testFormPart{
+			{name: "avatar", contentType: contentType, data: strings.NewReader(data), filename: "avatar"},
+		})
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/avatars", body)
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", mime)
+		route, pathParams, err := router.FindRoute(req)
+		require.NoError(t, err)
+		return ValidateRequest(context.Background(), &RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		})
+	}
+
+	require.NoError(t, validate("image/png", "\x89PNG\r\n"))
+
+	err := validate("image/png", "\x89PNG\r\n\x1a\n\x00")
+	require.Error(t, err)
+	var schemaErr *openapi3.SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	require.Equal(t, "maxLength", schemaErr.SchemaField)
+
+	err = validate("text/plain", "PNG")
+	require.Error(t, err)
+	var parseErr *ParseError
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, []interface{}{"avatar"}, parseErr.Path())
+}
@@ -5,8 +5,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io/ioutil"
 	"net/http"
+	"sort"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -61,18 +61,159 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return &ResponseError{Input: input, Reason: "response has not been resolved"}
 	}
 
+	var me openapi3.MultiError
+	headerNames := make([]string, 0, len(response.Headers))
+	for name := range response.Headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	for _, name := range headerNames {
+		ref := response.Headers[name]
+		if ref == nil {
+			continue
+		}
+		if err := ValidateResponseHeader(ctx, input, name, ref.Value); err != nil {
+			if !options.MultiError {
+				return err
+			}
+			me = append(me, err)
+		}
+	}
+
+	if options.RejectNotAcceptable {
+		if err := validateResponseAccept(input, response); err != nil {
+			if !options.MultiError {
+				return err
+			}
+			me = append(me, err)
+		}
+	}
+
+	if err := validateResponseBody(input, response, options); err != nil {
+		if !options.MultiError {
+			return err
+		}
+		me = append(me, err)
+	}
+
+	if len(me) > 0 {
+		return me
+	}
+	return nil
+}
+
+// ValidateResponseHeader validates a header of a response, declared with a name in the headers of the response.
+// Content-Type is not validated as a header, since the content of the response describes it.
+//
+// The function returns ResponseError with the name of the header and an ErrInvalidRequired cause when
+// a header is required but missing, a ParseError cause when a header does not decode,
+// or a openapi3.SchemaError cause when a value is invalid by JSON schema.
+func ValidateResponseHeader(ctx context.Context, input *ResponseValidationInput, name string, header *openapi3.Header) error {
+	name = http.CanonicalHeaderKey(name)
+	if header == nil || name == headerCT {
+		return nil
+	}
+	options := input.Options
+	if options == nil {
+		options = DefaultOptions
+	}
+
+	opts := make([]openapi3.SchemaValidationOption, 0, 3) // 3 potential opts here
+	opts = append(opts, openapi3.VisitAsResponse())
+	if options.MultiError {
+		opts = append(opts, openapi3.MultiErrors())
+	}
+	if header.Schema != nil && header.Schema.Ref != "" {
+		opts = append(opts, openapi3.SchemaLocation(header.Schema.Ref))
+	}
+	if err := validateHeader(input.Header, name, header, opts...); err != nil {
+		return &ResponseError{Input: input, Header: name, Err: err}
+	}
+	return nil
+}
+
+// validateHeader validates a header, declared with a name, of a response or of a part of a multipart body.
+// Headers are decoded the way header parameters of requests are.
+//
+// The function returns ErrInvalidRequired when a header is required but missing, ParseError when
+// a header does not decode, or the error of the schema of the header when a value is invalid.
+func validateHeader(h http.Header, name string, header *openapi3.Header, opts ...openapi3.SchemaValidationOption) error {
+	if len(h.Values(name)) == 0 {
+		if header.Required {
+			return ErrInvalidRequired
+		}
+		return nil
+	}
+
+	param := header.Parameter
+	param.Name, param.In = name, openapi3.ParameterInHeader
+	paramInput := &RequestValidationInput{Request: &http.Request{Header: h}}
+	var (
+		value  interface{}
+		schema *openapi3.Schema
+		err    error
+	)
+	switch {
+	case param.Content != nil:
+		value, schema, _, err = decodeContentParameter(&param, paramInput)
+	case param.Schema != nil:
+		value, _, err = decodeStyledParameter(&param, paramInput)
+		schema = param.Schema.Value
+	}
+	if err != nil {
+		if _, ok := err.(*ParseError); !ok {
+			err = &ParseError{Kind: KindInvalidFormat, Cause: err}
+		}
+		return err
+	}
+	if schema == nil || isNilValue(value) {
+		return nil
+	}
+	return schema.VisitJSON(value, opts...)
+}
+
+// validateResponseAccept validates that the Accept header of a request accepts the Content-Type of its response.
+func validateResponseAccept(input *ResponseValidationInput, response *openapi3.Response) error {
+	contentType := input.Header.Get(headerCT)
+	if len(response.Content) == 0 || contentType == "" {
+		return nil
+	}
+	ranges := parseAccept(input.RequestValidationInput.Request.Header.Values("Accept"))
+	if len(ranges) == 0 || acceptQuality(ranges, contentType) > 0 {
+		return nil
+	}
+	return &ResponseError{
+		Input:  input,
+		Header: headerCT,
+		Reason: fmt.Sprintf("%q is not accepted by the request", parseMediaType(contentType)),
+		Err:    ErrNotAcceptable,
+	}
+}
+
+func validateResponseBody(input *ResponseValidationInput, response *openapi3.Response, options *Options) error {
 	if options.ExcludeResponseBody {
 		// A user turned off validation of a response's body.
 		return nil
 	}
 
 	content := response.Content
-	if len(content) == 0 || options.ExcludeResponseBody {
+	if len(content) == 0 {
 		// An operation does not contains a validation schema for responses with this status code.
 		return nil
 	}
 
 	inputMIME := input.Header.Get(headerCT)
+	if inputMIME == "" && content["*/*"] == nil {
+		data, err := readResponseBody(input, options)
+		if err != nil {
+			return err
+		}
+		if len(data) == 0 {
+			// Responses without a body, such as 204 ones, need no Content-Type.
+			return nil
+		}
+		return &ResponseError{Input: input, Header: headerCT, Err: ErrInvalidRequired}
+	}
 	contentType := content.Get(inputMIME)
 	if contentType == nil {
 		return &ResponseError{
@@ -86,32 +227,13 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		return nil
 	}
 
-	// Read response's body.
-	body := input.Body
-
-	// Response would contain partial or empty input body
-	// after we begin reading.
-	// Ensure that this doesn't happen.
-	input.Body = nil
-
-	// Ensure we close the reader
-	defer body.Close()
-
-	// Read all
-	data, err := ioutil.ReadAll(body)
+	data, err := readResponseBody(input, options)
 	if err != nil {
-		return &ResponseError{
-			Input:  input,
-			Reason: "failed to read response body",
-			Err:    err,
-		}
+		return err
 	}
 
-	// Put the data back into the response.
-	input.SetBodyBytes(data)
-
 	encFn := func(name string) *openapi3.Encoding { return contentType.Encoding[name] }
-	value, err := decodeBody(bytes.NewBuffer(data), input.Header, contentType.Schema, encFn)
+	value, err := decodeBody(bytes.NewBuffer(data), input.Header, contentType.Schema, encFn, newBodyLimits(options))
 	if err != nil {
 		return &ResponseError{
 			Input:  input,
@@ -120,11 +242,17 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 		}
 	}
 
-	opts := make([]openapi3.SchemaValidationOption, 0, 2) // 2 potential opts here
-	opts = append(opts, openapi3.VisitAsRequest())
+	opts := make([]openapi3.SchemaValidationOption, 0, 4) // 4 potential opts here
+	opts = append(opts, openapi3.VisitAsResponse())
 	if options.MultiError {
 		opts = append(opts, openapi3.MultiErrors())
 	}
+	if options.RejectUnknownProperties {
+		opts = append(opts, openapi3.RejectUnknownProperties())
+	}
+	if ref := contentType.Schema.Ref; ref != "" {
+		opts = append(opts, openapi3.SchemaLocation(ref))
+	}
 
 	// Validate data with the schema.
 	if err := contentType.Schema.Value.VisitJSON(value, opts...); err != nil {
@@ -136,3 +264,34 @@ func ValidateResponse(ctx context.Context, input *ResponseValidationInput) error
 	}
 	return nil
 }
+
+// readResponseBody reads the body of a response, a nil one being empty, and puts its data back into the response.
+func readResponseBody(input *ResponseValidationInput, options *Options) ([]byte, error) {
+	// Read response's body.
+	body := input.Body
+	if body == nil {
+		return nil, nil
+	}
+
+	// Response would contain partial or empty input body
+	// after we begin reading.
+	// Ensure that this doesn't happen.
+	input.Body = nil
+
+	// Ensure we close the reader
+	defer body.Close()
+
+	// Read all
+	data, err := readBody(body, options.MaxBodyBytes)
+	if err != nil {
+		return nil, &ResponseError{
+			Input:  input,
+			Reason: "failed to read response body",
+			Err:    err,
+		}
+	}
+
+	// Put the data back into the response.
+	input.SetBodyBytes(data)
+	return data, nil
+}
@@ -0,0 +1,171 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestValidateResponseHeaders(t *testing.T) {
+	const spec = `
+openapi: 3.0.0
+info:
+  title: 'Validator'
+  version: 0.0.1
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+                minimum: 1
+            X-Tags:
+              schema:
+                type: array
+                items:
+                  type: string
+                maxItems: 2
+            X-Filter:
+              content:
+                application/json:
+                  schema:
+                    type: object
+                    required: [name]
+            Content-Type:
+              required: true
+              schema:
+                type: integer
+          content:
+            application/json:
+              schema:
+                type: array
+                items: {}
+        '204':
+          description: No content
+          headers:
+            X-Rate-Limit:
+              required: true
+              schema:
+                type: integer
+`
+
+	router := setupTestRouter(t, spec)
+	req, err := http.NewRequest(http.MethodGet, "/pets", nil)
+	require.NoError(t, err)
+	route, pathParams, err := router.FindRoute(req)
+	require.NoError(t, err)
+
+	validate := func(status int, header http.Header, options *Options) error {
+		input := &ResponseValidationInput{
+			RequestValidationInput: &RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+			},
+			Status:  status,
+			Header:  header,
+			Options: options,
+		}
+		if status == http.StatusOK {
+			input.SetBodyBytes([]byte(`[]`))
+		}
+		return ValidateResponse(context.Background(), input)
+	}
+	responseErr := func(t *testing.T, err error) *ResponseError {
+		require.Error(t, err)
+		var responseErr *ResponseError
+		require.True(t, errors.As(err, &responseErr))
+		return responseErr
+	}
+
+	err = validate(http.StatusOK, http.Header{
+		"Content-Type": {"application/json"},
+		"X-Rate-Limit": {"10"},
+		"X-Tags":       {"a,b"},
+		"X-Filter":     {`{"name":"Rex"}`},
+	}, nil)
+	require.NoError(t, err)
+
+	err = validate(http.StatusNoContent, http.Header{"X-Rate-Limit": {"10"}}, nil)
+	require.NoError(t, err)
+
+	err = validate(http.StatusNoContent, http.Header{}, nil)
+	e := responseErr(t, err)
+	require.Equal(t, "X-Rate-Limit", e.Header)
+	require.Equal(t, ErrInvalidRequired, e.Err)
+	require.Equal(t, `response header "X-Rate-Limit" has an error: value is required but missing`, e.Error())
+
+	err = validate(http.StatusOK, http.Header{
+		"Content-Type": {"application/json"},
+		"X-Rate-Limit": {"0"},
+	}, nil)
+	e = responseErr(t, err)
+	require.Equal(t, "X-Rate-Limit", e.Header)
+	var schemaErr *openapi3.SchemaError
+	require.True(t, errors.As(e, &schemaErr))
+	require.Equal(t, "minimum", schemaErr.SchemaField)
+
+	err = validate(http.StatusOK, http.Header{
+		"Content-Type": {"application/json"},
+		"X-Rate-Limit": {"many"},
+	}, nil)
+	e = responseErr(t, err)
+	require.Equal(t, "X-Rate-Limit", e.Header)
+	var parseErr *ParseError
+	require.True(t, errors.As(e, &parseErr))
+
+	err = validate(http.StatusOK, http.Header{
+		"Content-Type": {"application/json"},
+		"X-Rate-Limit": {"10"},
+		"X-Filter":     {`{}`},
+	}, nil)
+	e = responseErr(t, err)
+	require.Equal(t, "X-Filter", e.Header)
+
+	err = validate(http.StatusOK, http.Header{"X-Rate-Limit": {"10"}}, nil)
+	e = responseErr(t, err)
+	require.Equal(t, "Content-Type", e.Header)
+	require.Equal(t, ErrInvalidRequired, e.Err)
+
+	// Empty bodies need no Content-Type
+	input := &ResponseValidationInput{
+		RequestValidationInput: &RequestValidationInput{
+			Request:    req,
+			PathParams: pathParams,
+			Route:      route,
+		},
+		Status: http.StatusOK,
+		Header: http.Header{"X-Rate-Limit": {"10"}},
+	}
+	input.SetBodyBytes(nil)
+	err = ValidateResponse(context.Background(), input)
+	require.NoError(t, err)
+	input.Body = nil
+	err = ValidateResponse(context.Background(), input)
+	require.NoError(t, err)
+
+	err = validate(http.StatusOK, http.Header{"X-Tags": {"a,b,c"}}, &Options{MultiError: true})
+	require.Error(t, err)
+	var me openapi3.MultiError
+	require.True(t, errors.As(err, &me))
+	var headers []string
+	for _, err := range me {
+		headers = append(headers, responseErr(t, err).Header)
+	}
+	require.Equal(t, []string{"X-Rate-Limit", "X-Tags", "Content-Type"}, headers)
+
+	// Headers without a definition are not validated
+	route.Operation.Responses.Get(http.StatusNoContent).Value.Headers["X-Undefined"] = nil
+	err = validate(http.StatusNoContent, http.Header{"X-Rate-Limit": {"10"}}, nil)
+	require.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package openapi3filter
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -36,6 +37,8 @@ func (enc *ValidationErrorEncoder) Encode(ctx context.Context, err error, w http
 		cErr = convertErrInvalidRequired(e)
 	} else if e.Err == ErrInvalidEmptyValue {
 		cErr = convertErrInvalidEmptyValue(e)
+	} else if e.Err == ErrNotAcceptable {
+		cErr = &ValidationError{Status: http.StatusNotAcceptable, Title: e.Error()}
 	} else if innerErr, ok := e.Err.(*ParseError); ok {
 		cErr = convertParseError(e, innerErr)
 	} else if innerErr, ok := e.Err.(*openapi3.SchemaError); ok {
@@ -103,6 +106,18 @@ func convertErrInvalidEmptyValue(e *RequestError) *ValidationError {
 }
 
 func convertParseError(e *RequestError, innerErr *ParseError) *ValidationError {
+	switch innerErr.Kind {
+	case KindBodyTooLarge:
+		return &ValidationError{
+			Status: http.StatusRequestEntityTooLarge,
+			Title:  innerErr.Reason,
+		}
+	case KindLimitExceeded:
+		return &ValidationError{
+			Status: http.StatusBadRequest,
+			Title:  innerErr.Error(),
+		}
+	}
 	// We treat path params of the wrong type like a 404 instead of a 400
 	if innerErr.Kind == KindInvalidFormat && e.Parameter != nil && e.Parameter.In == "path" {
 		return &ValidationError{
@@ -132,6 +147,10 @@ func convertParseError(e *RequestError, innerErr *ParseError) *ValidationError {
 }
 
 func convertSchemaError(e *RequestError, innerErr *openapi3.SchemaError) *ValidationError {
+	if len(innerErr.Branches) > 0 {
+		return convertSchemaBranchesError(e, innerErr)
+	}
+
 	cErr := &ValidationError{Title: innerErr.Reason}
 
 	// Handle "Origin" error
@@ -180,6 +199,55 @@ func convertSchemaError(e *RequestError, innerErr *openapi3.SchemaError) *Valida
 	return cErr
 }
 
+// convertSchemaBranchesError explains a "oneOf" or "anyOf" failure through
+// the errors of the closest candidate, or through the list of candidates
+// that matched when more than one "oneOf" candidate did.
+func convertSchemaBranchesError(e *RequestError, innerErr *openapi3.SchemaError) *ValidationError {
+	if closest := innerErr.ClosestBranch(); closest != nil {
+		var cErr *ValidationError
+		var schemaErr *openapi3.SchemaError
+		if errors.As(closest.Err, &schemaErr) {
+			cErr = convertSchemaError(e, schemaErr)
+		} else {
+			cErr = &ValidationError{Title: closest.Err.Error()}
+			if e.Parameter != nil {
+				cErr.Status = http.StatusBadRequest
+				cErr.Source = &ValidationErrorSource{Parameter: e.Parameter.Name}
+			} else if e.RequestBody != nil {
+				cErr.Status = http.StatusUnprocessableEntity
+			}
+		}
+		detail := fmt.Sprintf("value doesn't match any of the %s schemas, closest match is %s",
+			innerErr.SchemaField, closest.Name())
+		if cErr.Detail != "" {
+			detail += ": " + cErr.Detail
+		}
+		cErr.Detail = detail
+		return cErr
+	}
+
+	names := make([]string, 0, len(innerErr.Branches))
+	for _, branch := range innerErr.Branches {
+		names = append(names, branch.Name())
+	}
+	cErr := &ValidationError{
+		Title:  fmt.Sprintf("value matches more than one %s schema", innerErr.SchemaField),
+		Detail: fmt.Sprintf("value matches %s", strings.Join(names, ", ")),
+	}
+	if e.Parameter != nil {
+		cErr.Status = http.StatusBadRequest
+		cErr.Source = &ValidationErrorSource{Parameter: e.Parameter.Name}
+	} else {
+		if e.RequestBody != nil {
+			cErr.Status = http.StatusUnprocessableEntity
+		}
+		if ptr := innerErr.JSONPointer(); ptr != nil {
+			cErr.Source = &ValidationErrorSource{Pointer: toJSONPointer(ptr)}
+		}
+	}
+	return cErr
+}
+
 func toJSONPointer(reversePath []string) string {
 	return "/" + strings.Join(reversePath, "/")
 }
@@ -71,7 +71,7 @@ func getValidationTests(t *testing.T) []*validationTest {
 	noContentTypeNeeded.Header.Del(headerCT)
 
 	unknownContentType := newPetstoreRequest(t, http.MethodPost, "/pet", bytes.NewBufferString(`{}`))
-	unknownContentType.Header.Set(headerCT, "application/xml")
+	unknownContentType.Header.Set(headerCT, "application/x-protobuf")
 
 	unsupportedContentType := newPetstoreRequest(t, http.MethodPost, "/pet", bytes.NewBufferString(`{}`))
 	unsupportedContentType.Header.Set(headerCT, "text/plain")
@@ -150,9 +150,9 @@ func getValidationTests(t *testing.T) []*validationTest {
 			},
 			wantErrReason:      "failed to decode request body",
 			wantErrParseKind:   KindUnsupportedFormat,
-			wantErrParseReason: prefixUnsupportedCT + ` "application/xml"`,
+			wantErrParseReason: prefixUnsupportedCT + ` "application/x-protobuf"`,
 			wantErrResponse: &ValidationError{Status: http.StatusUnsupportedMediaType,
-				Title: prefixUnsupportedCT + ` "application/xml"`},
+				Title: prefixUnsupportedCT + ` "application/x-protobuf"`},
 		},
 		{
 			name: "error - unsupported content-type on POST",
@@ -713,3 +713,46 @@ func TestValidationHandler_Middleware(t *testing.T) {
 		require.Equal(t, "[422][][] Field must be set to array or not be present [source pointer=/photoUrls]", string(body))
 	})
 }
+
+func TestValidationErrorEncoder_OneOf(t *testing.T) {
+	schema := openapi3.NewObjectSchema().WithProperty("pet", &openapi3.Schema{
+		OneOf: openapi3.SchemaRefs{
+			openapi3.NewSchemaRef("#/components/schemas/Count", openapi3.NewIntegerSchema()),
+			openapi3.NewSchemaRef("#/components/schemas/Pet", openapi3.NewObjectSchema().
+				WithProperty("name", openapi3.NewStringSchema()).
+				WithProperty("tag", openapi3.NewStringSchema())),
+		},
+	})
+	requestBody := openapi3.NewRequestBody().WithJSONSchema(schema)
+
+	encode := func(value interface{}) error {
+		schemaErr := schema.VisitJSON(value)
+		require.Error(t, schemaErr)
+		mockEncoder := &mockErrorEncoder{}
+		encoder := &ValidationErrorEncoder{Encoder: mockEncoder.Encode}
+		encoder.Encode(context.Background(), &RequestError{RequestBody: requestBody, Err: schemaErr}, httptest.NewRecorder())
+		return mockEncoder.Err
+	}
+
+	t.Run("closest match", func(t *testing.T) {
+		err := encode(map[string]interface{}{"pet": map[string]interface{}{"name": 42.0}})
+		require.Equal(t, &ValidationError{
+			Status: http.StatusUnprocessableEntity,
+			Title:  "Field must be set to string or not be present",
+			Detail: "value doesn't match any of the oneOf schemas, closest match is #/components/schemas/Pet",
+			Source: &ValidationErrorSource{Pointer: "/pet/name"},
+		}, err)
+	})
+
+	t.Run("more than one match", func(t *testing.T) {
+		schema.Properties["pet"].Value.OneOf[0].Value = openapi3.NewObjectSchema()
+		defer func() { schema.Properties["pet"].Value.OneOf[0].Value = openapi3.NewIntegerSchema() }()
+		err := encode(map[string]interface{}{"pet": map[string]interface{}{"name": "Bahama"}})
+		require.Equal(t, &ValidationError{
+			Status: http.StatusUnprocessableEntity,
+			Title:  "value matches more than one oneOf schema",
+			Detail: "value matches #/components/schemas/Count, #/components/schemas/Pet",
+			Source: &ValidationErrorSource{Pointer: "/pet"},
+		}, err)
+	})
+}
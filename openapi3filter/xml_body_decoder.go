@@ -0,0 +1,349 @@
+package openapi3filter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// xmlElement is an element of an XML document.
+type xmlElement struct {
+	name     xml.Name
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// xmlBodyDecoder decodes an XML body to the JSON data model, the way the schema's XML objects describe it:
+// properties are child elements or, with attribute, attributes; arrays are repeated elements,
+// inside a wrapping element with wrapped; elements and attributes are renamed with name;
+// and with namespace, only elements and attributes of the namespace match.
+// Elements without a property are decoded as strings, or as objects of their child elements.
+func xmlBodyDecoder(body io.Reader, header http.Header, schema *openapi3.SchemaRef, encFn EncodingFn, limits *bodyLimits) (interface{}, error) {
+	// A body with a charset in its Content-Type is decoded to UTF-8 already,
+	// which overrides the encoding its XML declaration gives.
+	root, err := parseXML(body, mediaTypeCharset(header.Get(headerCT)) != "", limits)
+	if err != nil {
+		if _, ok := err.(*ParseError); ok {
+			return nil, err
+		}
+		return nil, &ParseError{Kind: KindInvalidFormat, Cause: err}
+	}
+	if s := schemaValue(schema); s != nil && s.XML != nil {
+		if !xmlNameMatches(root.name, s.XML.Name, s.XML.Namespace) {
+			return nil, &ParseError{
+				Kind:   KindInvalidFormat,
+				Reason: fmt.Sprintf("unexpected root element %q", root.name.Local),
+			}
+		}
+	}
+	return xmlValue(root, schema), nil
+}
+
+// parseXML parses the elements of an XML document, enforcing limits as it goes:
+// elements and attributes count as values, and their names and texts as strings.
+func parseXML(r io.Reader, decoded bool, limits *bodyLimits) (*xmlElement, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if decoded {
+			return input, nil
+		}
+		return charsetReader(charset, input)
+	}
+	var root *xmlElement
+	var stack []*xmlElement
+	var path []interface{}
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if len(stack) >= maxNesting {
+				return nil, fmt.Errorf("elements are nested deeper than %d levels", maxNesting)
+			}
+			elem := &xmlElement{name: t.Name, attrs: t.Attr}
+			if len(stack) == 0 {
+				if root != nil {
+					return nil, fmt.Errorf("unexpected element %q after the root element", t.Name.Local)
+				}
+				root = elem
+				if err := limits.countValue(path); err != nil {
+					return nil, err
+				}
+			} else {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, elem)
+				if err := limits.checkDepth(len(stack), path); err != nil {
+					return nil, err
+				}
+				path = appendPath(path, t.Name.Local)
+				if err := limits.countValue(path); err != nil {
+					return nil, err
+				}
+				if err := limits.checkString(t.Name.Local, path); err != nil {
+					return nil, err
+				}
+			}
+			for _, attr := range t.Attr {
+				attrPath := appendPath(path, attr.Name.Local)
+				if err := limits.countValue(attrPath); err != nil {
+					return nil, err
+				}
+				if err := limits.checkString(attr.Name.Local, attrPath); err != nil {
+					return nil, err
+				}
+				if err := limits.checkString(attr.Value, attrPath); err != nil {
+					return nil, err
+				}
+			}
+			stack = append(stack, elem)
+		case xml.EndElement:
+			if stack = stack[:len(stack)-1]; len(stack) != 0 {
+				path = path[:len(path)-1]
+			}
+		case xml.CharData:
+			if len(stack) != 0 {
+				text := &stack[len(stack)-1].text
+				text.Write(t)
+				if err := limits.checkStringLength(text.Len(), path); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if root == nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return root, nil
+}
+
+func schemaValue(schema *openapi3.SchemaRef) *openapi3.Schema {
+	if schema == nil {
+		return nil
+	}
+	return schema.Value
+}
+
+// xmlNameMatches tells whether the name of an element or attribute is the given one.
+// Names match in any namespace unless a namespace is given.
+func xmlNameMatches(name xml.Name, local, namespace string) bool {
+	if local != "" && name.Local != local {
+		return false
+	}
+	return namespace == "" || name.Space == namespace
+}
+
+// xmlType returns the type of a schema, inferred from its keywords if it has none.
+func xmlType(schema *openapi3.Schema) string {
+	switch {
+	case schema.Type != "":
+		return schema.Type
+	case schema.Items != nil:
+		return "array"
+	case len(schemaProperties(schema)) != 0 || schema.AdditionalProperties != nil:
+		return "object"
+	}
+	for _, schemas := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, s := range schemas {
+			if s.Value != nil {
+				if typ := xmlType(s.Value); typ != "" {
+					return typ
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// schemaProperties returns the properties of a schema and of the schemas it is composed of.
+func schemaProperties(schema *openapi3.Schema) openapi3.Schemas {
+	properties := make(openapi3.Schemas, len(schema.Properties))
+	for _, schemas := range []openapi3.SchemaRefs{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, s := range schemas {
+			if s.Value != nil {
+				for name, property := range schemaProperties(s.Value) {
+					properties[name] = property
+				}
+			}
+		}
+	}
+	for name, property := range schema.Properties {
+		properties[name] = property
+	}
+	return properties
+}
+
+// xmlValue decodes an element to the value its schema describes.
+func xmlValue(elem *xmlElement, schema *openapi3.SchemaRef) interface{} {
+	s := schemaValue(schema)
+	if s == nil {
+		return xmlUntypedValue(elem)
+	}
+	switch xmlType(s) {
+	case "object":
+		return xmlObject(elem, s)
+	case "array":
+		var items *openapi3.XML
+		if s.Items != nil && s.Items.Value != nil {
+			items = s.Items.Value.XML
+		}
+		values := make([]interface{}, 0, len(elem.children))
+		for _, child := range elem.children {
+			if items == nil || xmlNameMatches(child.name, items.Name, items.Namespace) {
+				values = append(values, xmlValue(child, s.Items))
+			}
+		}
+		return values
+	case "":
+		return xmlUntypedValue(elem)
+	}
+	return xmlScalar(elem.text.String(), s)
+}
+
+func xmlObject(elem *xmlElement, schema *openapi3.Schema) map[string]interface{} {
+	obj := make(map[string]interface{})
+	matched := make(map[*xmlElement]struct{})
+	for name, property := range schemaProperties(schema) {
+		p := property.Value
+		if p == nil {
+			continue
+		}
+		local, namespace := name, ""
+		if x := p.XML; x != nil {
+			if x.Name != "" {
+				local = x.Name
+			}
+			namespace = x.Namespace
+		}
+
+		if p.XML != nil && p.XML.Attribute {
+			for _, attr := range elem.attrs {
+				if xmlNameMatches(attr.Name, local, namespace) {
+					obj[name] = xmlScalar(attr.Value, p)
+					break
+				}
+			}
+			continue
+		}
+
+		if xmlType(p) == "array" && (p.XML == nil || !p.XML.Wrapped) {
+			// Unwrapped arrays are repeated elements named after their items
+			var items *openapi3.Schema
+			if p.Items != nil {
+				items = p.Items.Value
+			}
+			itemLocal, itemNamespace := local, namespace
+			if items != nil && items.XML != nil {
+				if items.XML.Name != "" {
+					itemLocal = items.XML.Name
+				}
+				if items.XML.Namespace != "" {
+					itemNamespace = items.XML.Namespace
+				}
+			}
+			var values []interface{}
+			for _, child := range elem.children {
+				if xmlNameMatches(child.name, itemLocal, itemNamespace) {
+					matched[child] = struct{}{}
+					values = append(values, xmlValue(child, p.Items))
+				}
+			}
+			if values != nil {
+				obj[name] = values
+			}
+			continue
+		}
+
+		for _, child := range elem.children {
+			if xmlNameMatches(child.name, local, namespace) {
+				matched[child] = struct{}{}
+				if xmlType(p) == "array" {
+					obj[name] = xmlWrappedArray(child, name, p)
+				} else {
+					obj[name] = xmlValue(child, property)
+				}
+				break
+			}
+		}
+	}
+
+	// Other elements are kept for additionalProperties to apply to them
+	for _, child := range elem.children {
+		if _, ok := matched[child]; ok {
+			continue
+		}
+		if _, ok := obj[child.name.Local]; ok {
+			continue
+		}
+		if schema.AdditionalProperties != nil {
+			obj[child.name.Local] = xmlValue(child, schema.AdditionalProperties)
+		} else {
+			obj[child.name.Local] = xmlUntypedValue(child)
+		}
+	}
+	return obj
+}
+
+// xmlWrappedArray decodes the items of a wrapped array, which are named
+// after the items or, by default, after the array property.
+func xmlWrappedArray(wrapper *xmlElement, name string, schema *openapi3.Schema) []interface{} {
+	local, namespace := name, ""
+	if x := schema.XML; x != nil && x.Name != "" {
+		local = x.Name
+	}
+	if schema.Items != nil && schema.Items.Value != nil {
+		if x := schema.Items.Value.XML; x != nil {
+			if x.Name != "" {
+				local = x.Name
+			}
+			namespace = x.Namespace
+		}
+	}
+	values := make([]interface{}, 0, len(wrapper.children))
+	for _, child := range wrapper.children {
+		if xmlNameMatches(child.name, local, namespace) {
+			values = append(values, xmlValue(child, schema.Items))
+		}
+	}
+	return values
+}
+
+// xmlUntypedValue decodes an element without a schema: an element with child elements
+// is an object of them, repeated ones in arrays, and another element is its text.
+func xmlUntypedValue(elem *xmlElement) interface{} {
+	if len(elem.children) == 0 {
+		return elem.text.String()
+	}
+	obj := make(map[string]interface{}, len(elem.children))
+	for _, child := range elem.children {
+		value := xmlUntypedValue(child)
+		switch v := obj[child.name.Local].(type) {
+		case nil:
+			obj[child.name.Local] = value
+		case []interface{}:
+			obj[child.name.Local] = append(v, value)
+		default:
+			obj[child.name.Local] = []interface{}{v, value}
+		}
+	}
+	return obj
+}
+
+// xmlScalar converts text, trimmed of spaces, to the type of a schema with Schema.CoerceValue.
+// Text that does not convert is kept as a string for validation to report it.
+func xmlScalar(text string, schema *openapi3.Schema) interface{} {
+	v, err := schema.CoerceValue(strings.TrimSpace(text))
+	if _, ok := v.(string); ok || err != nil {
+		return text
+	}
+	return v
+}
@@ -0,0 +1,144 @@
+package openapi3filter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const xmlPetSchema = `{
+  "type": "object",
+  "xml": {"name": "pet", "namespace": "http://example.com/pets"},
+  "required": ["id", "name"],
+  "properties": {
+    "id": {"type": "integer", "xml": {"attribute": true}},
+    "name": {"type": "string", "xml": {"name": "Name"}},
+    "available": {"type": "boolean"},
+    "weight": {"allOf": [{"type": "number"}, {"minimum": 0}]},
+    "tags": {"type": "array", "xml": {"wrapped": true}, "items": {"type": "string", "xml": {"name": "tag"}}},
+    "aliases": {"type": "array", "xml": {"wrapped": true}, "items": {"type": "string"}},
+    "photos": {"type": "array", "items": {"type": "string", "xml": {"name": "photo"}}},
+    "owner": {"type": "object", "properties": {"email": {"type": "string"}}}
+  },
+  "additionalProperties": false
+}`
+
+func TestXMLBodyDecoder(t *testing.T) {
+	var schema openapi3.Schema
+	err := schema.UnmarshalJSON([]byte(xmlPetSchema))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		body    string
+		want    interface{}
+		wantErr *ParseError
+	}{
+		{
+			name: "namespaced",
+			body: `<?xml version="1.0"?>
+<p:pet xmlns:p="http://example.com/pets" id="42">
+  <p:Name>Rex</p:Name>
+  <p:available>true</p:available>
+  <p:tags><p:tag>a</p:tag><p:tag>b</p:tag></p:tags>
+  <p:aliases><p:aliases>Rexy</p:aliases></p:aliases>
+  <p:photo>x.png</p:photo>
+  <p:photo>y.png</p:photo>
+  <p:owner><p:email>me@example.com</p:email></p:owner>
+</p:pet>`,
+			want: map[string]interface{}{
+				"id":        42.0,
+				"name":      "Rex",
+				"available": true,
+				"tags":      []interface{}{"a", "b"},
+				"aliases":   []interface{}{"Rexy"},
+				"photos":    []interface{}{"x.png", "y.png"},
+				"owner":     map[string]interface{}{"email": "me@example.com"},
+			},
+		},
+		{
+			name: "default namespace",
+			body: `<pet xmlns="http://example.com/pets" id="7"><Name>Tom</Name><color>grey</color></pet>`,
+			want: map[string]interface{}{
+				"id":    7.0,
+				"name":  "Tom",
+				"color": "grey",
+			},
+		},
+		{
+			name: "type of allOf",
+			body: `<pet xmlns="http://example.com/pets" id="7"><Name>Tom</Name><weight> 4.5 </weight></pet>`,
+			want: map[string]interface{}{
+				"id":     7.0,
+				"name":   "Tom",
+				"weight": 4.5,
+			},
+		},
+		{
+			name: "unconverted text",
+			body: `<pet xmlns="http://example.com/pets" id="seven"><Name>Tom</Name></pet>`,
+			want: map[string]interface{}{
+				"id":   "seven",
+				"name": "Tom",
+			},
+		},
+		{
+			name:    "other namespace",
+			body:    `<pet xmlns="http://example.com/cats" id="7"/>`,
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+		{
+			name:    "malformed",
+			body:    `<pet xmlns="http://example.com/pets" id="7">`,
+			wantErr: &ParseError{Kind: KindInvalidFormat},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := make(http.Header)
+			h.Set(headerCT, "application/xml")
+			got, err := decodeBody(strings.NewReader(tc.body), h, schema.NewRef(), nil, nil)
+			if tc.wantErr != nil {
+				require.Error(t, err)
+				require.Truef(t, matchParseError(err, tc.wantErr), "got error:\n%v\nwant error:\n%v", err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateXMLRequestBody(t *testing.T) {
+	var schema openapi3.Schema
+	err := schema.UnmarshalJSON([]byte(xmlPetSchema))
+	require.NoError(t, err)
+	requestBody := openapi3.NewRequestBody().WithContent(openapi3.Content{
+		"text/xml": openapi3.NewMediaType().WithSchema(&schema),
+	})
+
+	validate := func(body string) error {
+		req, err := http.NewRequest(http.MethodPost, "/pets", strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set(headerCT, "text/xml")
+		return ValidateRequestBody(context.Background(), &RequestValidationInput{Request: req}, requestBody)
+	}
+
+	err = validate(`<pet xmlns="http://example.com/pets" id="7"><Name>Tom</Name></pet>`)
+	require.NoError(t, err)
+
+	err = validate(`<pet xmlns="http://example.com/pets" id="seven"><Name>Tom</Name></pet>`)
+	require.Error(t, err)
+	var schemaErr *openapi3.SchemaError
+	require.True(t, errors.As(err, &schemaErr))
+	require.Equal(t, []string{"id"}, schemaErr.JSONPointer())
+
+	err = validate(`<pet xmlns="http://example.com/pets" id="7"><Name>Tom</Name><color>grey</color></pet>`)
+	require.Error(t, err)
+}
@@ -0,0 +1,90 @@
+package openapi3gen
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ComponentNameFn returns the name under which a type is defined in #/components/schemas.
+type ComponentNameFn func(t reflect.Type) string
+
+// ShortComponentName names a component after its Go type, without its package.
+// The type arguments of generic types are appended to the name:
+// Page[pkg.Item] is named Page_Item.
+func ShortComponentName(t reflect.Type) string {
+	return sanitizeComponentName(t.Name())
+}
+
+// PackageQualifiedComponentName names a component after its Go type
+// prefixed with the name of its package, as in pkg.Item.
+func PackageQualifiedComponentName(t reflect.Type) string {
+	name := ShortComponentName(t)
+	if pkg := t.PkgPath(); pkg != "" {
+		name = path.Base(pkg) + "." + name
+	}
+	return name
+}
+
+var (
+	// qualifiersRegexp matches the package paths within type arguments
+	qualifiersRegexp = regexp.MustCompile(`[\w.\-/]*[/.]`)
+	// invalidCharsRegexp matches runs of characters not allowed in component names
+	invalidCharsRegexp = regexp.MustCompile(`[^a-zA-Z0-9._\-]+`)
+)
+
+func sanitizeComponentName(name string) string {
+	i := strings.IndexByte(name, '[')
+	if i < 0 {
+		return name
+	}
+	args := qualifiersRegexp.ReplaceAllString(name[i:], "")
+	args = invalidCharsRegexp.ReplaceAllString(args, "_")
+	return name[:i] + strings.TrimRight(args, "_")
+}
+
+// componentName returns the name of the component defining a type,
+// making sure no other type is defined under the same name.
+func (g *Generator) componentName(t reflect.Type) (string, error) {
+	fn := g.opts.componentName
+	if fn == nil {
+		fn = ShortComponentName
+	}
+	name := fn(t)
+	if name == "" {
+		return "", fmt.Errorf("type %s has no name to be defined in the components", t)
+	}
+	if err := openapi3.ValidateIdentifier(name); err != nil {
+		return "", fmt.Errorf("type %s: %w", t, err)
+	}
+	if other, ok := g.componentTypes[name]; ok && other != t {
+		return "", fmt.Errorf("component name %q is used by both %s and %s", name, typeString(other), typeString(t))
+	}
+	g.componentTypes[name] = t
+	return name, nil
+}
+
+// componentRef defines the schema of a type in the components and references it.
+func (g *Generator) componentRef(t reflect.Type, schema *openapi3.Schema) (*openapi3.SchemaRef, error) {
+	name, err := g.componentName(t)
+	if err != nil {
+		return nil, err
+	}
+	g.componentSchemas[name] = schema
+	return openapi3.NewSchemaRef("#/components/schemas/"+name, schema), nil
+}
+
+func isNamedStruct(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t.Name() != "" && t != timeType
+}
+
+func typeString(t reflect.Type) string {
+	if pkg := t.PkgPath(); pkg != "" {
+		return pkg + "." + t.Name()
+	}
+	return t.String()
+}
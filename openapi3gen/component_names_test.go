@@ -0,0 +1,136 @@
+package openapi3gen
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+type Item struct {
+	Name string `json:"name"`
+}
+
+type Address struct {
+	City string `json:"city"`
+}
+
+func TestSanitizeComponentName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"Item":                               "Item",
+		"Page[int]":                          "Page_int",
+		"Page[github.com/org/repo/pkg.Item]": "Page_Item",
+		"Pair[string,map[string]*pkg.Item]":  "Pair_string_map_string_Item",
+		"Page[github.com/org/repo/pkg.Item[int]]": "Page_Item_int",
+	} {
+		require.Equal(t, expected, sanitizeComponentName(name), name)
+	}
+}
+
+func TestHoistNamedStructs(t *testing.T) {
+	type Order struct {
+		Items    []Item   `json:"items"`
+		Billing  Address  `json:"billing"`
+		Shipping *Address `json:"shipping"`
+	}
+
+	schemas := make(openapi3.Schemas)
+	schemaRef, err := NewSchemaRefForValue(&Order{}, schemas, HoistNamedStructs())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schemaRef)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "items": {"type": "array", "items": {"$ref": "#/components/schemas/Item"}},
+    "billing": {"$ref": "#/components/schemas/Address"},
+    "shipping": {"$ref": "#/components/schemas/Address"}
+  }
+}`, string(data))
+
+	data, err = json.Marshal(schemas)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "Address": {"type": "object", "properties": {"city": {"type": "string"}}},
+  "Item": {"type": "object", "properties": {"name": {"type": "string"}}}
+}`, string(data))
+}
+
+func TestComponentNameStrategies(t *testing.T) {
+	type Cart struct {
+		Items []Item `json:"items"`
+	}
+
+	schemas := make(openapi3.Schemas)
+	_, err := NewSchemaRefForValue(&Cart{}, schemas, HoistNamedStructs(), ComponentName(PackageQualifiedComponentName))
+	require.NoError(t, err)
+	require.Contains(t, schemas, "openapi3gen.Item")
+
+	schemas = make(openapi3.Schemas)
+	_, err = NewSchemaRefForValue(&Cart{}, schemas, HoistNamedStructs(), ComponentName(func(t reflect.Type) string {
+		return "V1" + t.Name()
+	}))
+	require.NoError(t, err)
+	require.Contains(t, schemas, "V1Item")
+
+	_, err = NewSchemaRefForValue(&Cart{}, schemas, HoistNamedStructs(), ComponentName(func(t reflect.Type) string {
+		return "v1/" + t.Name()
+	}))
+	require.EqualError(t, err, `type openapi3gen.Item: identifier "v1/Item" is not supported by OpenAPIv3 standard (regexp: "^[a-zA-Z0-9._-]+$")`)
+}
+
+func TestComponentNameCollision(t *testing.T) {
+	type first struct {
+		Item Item `json:"item"`
+	}
+	type Item struct {
+		ID int `json:"id"`
+	}
+	type both struct {
+		First first `json:"first"`
+		Item  Item  `json:"item"`
+	}
+
+	_, err := NewSchemaRefForValue(&both{}, nil)
+	require.NoError(t, err)
+
+	_, err = NewSchemaRefForValue(&both{}, nil, HoistNamedStructs())
+	require.EqualError(t, err, `component name "Item" is used by both github.com/getkin/kin-openapi/openapi3gen.Item and github.com/getkin/kin-openapi/openapi3gen.Item`)
+}
+
+type Husband struct {
+	Name string `json:"name"`
+	Wife *Wife  `json:"wife"`
+}
+
+type Wife struct {
+	Name    string   `json:"name"`
+	Husband *Husband `json:"husband"`
+}
+
+func TestMutuallyRecursiveComponents(t *testing.T) {
+	schemas := make(openapi3.Schemas)
+	_, err := NewSchemaRefForValue(&Husband{}, schemas)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schemas)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "Husband": {
+    "type": "object",
+    "properties": {
+      "name": {"type": "string"},
+      "wife": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "husband": {"$ref": "#/components/schemas/Husband"}
+        }
+      }
+    }
+  }
+}`, string(data))
+}
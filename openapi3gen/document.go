@@ -0,0 +1,262 @@
+package openapi3gen
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Operation describes an API operation by the Go types of its request and responses.
+type Operation struct {
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+
+	// Request is a struct (or a pointer to one) whose fields are tagged with
+	// the location of the parameter they hold: path:"name", query:"name",
+	// header:"name" or cookie:"name". The field tagged body:"" holds the
+	// request body; the tag value sets its media type, defaulting to application/json.
+	// Parameters and the body honor the openapi (and validate) tags of their field.
+	Request interface{}
+
+	// Responses maps status codes to a value of the type of the response body,
+	// or to nil for responses without a body. Status code 0 is the default response.
+	Responses map[int]interface{}
+}
+
+// DocumentGenerator builds an OpenAPI document from the Go types of its operations.
+// Named struct types of request and response bodies are defined in #/components/schemas,
+// under names that must be unique across the document.
+type DocumentGenerator struct {
+	opts []Option
+	doc  *openapi3.T
+
+	// components maps the types defined in the components to their name
+	components map[reflect.Type]string
+	// componentTypes maps the names of the components to their type,
+	// shared by the generators of all the schemas of the document
+	componentTypes map[string]reflect.Type
+}
+
+// NewDocumentGenerator creates a DocumentGenerator whose schemas are generated with the given options.
+func NewDocumentGenerator(info *openapi3.Info, opts ...Option) *DocumentGenerator {
+	return &DocumentGenerator{
+		opts: opts,
+		doc: &openapi3.T{
+			OpenAPI: "3.0.3",
+			Info:    info,
+			Paths:   openapi3.Paths{},
+			Components: openapi3.Components{
+				Schemas: openapi3.Schemas{},
+			},
+		},
+		components:     make(map[reflect.Type]string),
+		componentTypes: make(map[string]reflect.Type),
+	}
+}
+
+// AddOperation adds an operation to the document.
+func (dg *DocumentGenerator) AddOperation(method, path string, op Operation) error {
+	operation := openapi3.NewOperation()
+	operation.OperationID = op.OperationID
+	operation.Summary = op.Summary
+	operation.Description = op.Description
+	operation.Tags = op.Tags
+	operation.Responses = make(openapi3.Responses, len(op.Responses))
+
+	if op.Request != nil {
+		t := reflect.TypeOf(op.Request)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() != reflect.Struct {
+			return fmt.Errorf("operation %s %s: request must be a struct, got %s", method, path, t)
+		}
+		if err := dg.addRequestFields(operation, t); err != nil {
+			return fmt.Errorf("operation %s %s: %w", method, path, err)
+		}
+	}
+
+	statuses := make([]int, 0, len(op.Responses))
+	for status := range op.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Ints(statuses)
+	for _, status := range statuses {
+		value := op.Responses[status]
+		description := http.StatusText(status)
+		if status == 0 {
+			description = "Default response"
+		}
+		response := openapi3.NewResponse().WithDescription(description)
+		if value != nil {
+			ref, err := dg.bodySchemaRef(reflect.TypeOf(value))
+			if err != nil {
+				return fmt.Errorf("operation %s %s: response %d: %w", method, path, status, err)
+			}
+			response.WithJSONSchemaRef(ref)
+		}
+		operation.AddResponse(status, response)
+	}
+
+	dg.doc.AddOperation(path, method, operation)
+	return nil
+}
+
+// Document returns the generated document, after checking it is valid.
+func (dg *DocumentGenerator) Document(ctx context.Context) (*openapi3.T, error) {
+	if err := openapi3.NewLoader().ResolveRefsIn(dg.doc, nil); err != nil {
+		return nil, err
+	}
+	if err := dg.doc.Validate(ctx); err != nil {
+		return nil, err
+	}
+	return dg.doc, nil
+}
+
+func (dg *DocumentGenerator) addRequestFields(operation *openapi3.Operation, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			ft := field.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if err := dg.addRequestFields(operation, ft); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+
+		if contentType, ok := field.Tag.Lookup("body"); ok {
+			if operation.RequestBody != nil {
+				return fmt.Errorf("field %s: request has more than one body", field.Name)
+			}
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			ref, err := dg.bodySchemaRef(field.Type)
+			if err != nil {
+				return fmt.Errorf("field %s: %w", field.Name, err)
+			}
+			requestBody := openapi3.NewRequestBody().
+				WithRequired(field.Type.Kind() != reflect.Ptr).
+				WithSchemaRef(ref, []string{contentType})
+			operation.RequestBody = &openapi3.RequestBodyRef{Value: requestBody}
+			continue
+		}
+
+		parameter, err := dg.parameterFor(field)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if parameter != nil {
+			operation.AddParameter(parameter)
+		}
+	}
+	return nil
+}
+
+// parameterFor returns the parameter held by a field, or nil if the field is not tagged as one.
+func (dg *DocumentGenerator) parameterFor(field reflect.StructField) (*openapi3.Parameter, error) {
+	var parameter *openapi3.Parameter
+	if name, ok := field.Tag.Lookup("path"); ok {
+		parameter = openapi3.NewPathParameter(name)
+	} else if name, ok := field.Tag.Lookup("query"); ok {
+		parameter = openapi3.NewQueryParameter(name)
+	} else if name, ok := field.Tag.Lookup("header"); ok {
+		parameter = openapi3.NewHeaderParameter(name)
+	} else if name, ok := field.Tag.Lookup("cookie"); ok {
+		parameter = openapi3.NewCookieParameter(name)
+	} else {
+		return nil, nil
+	}
+	if parameter.Name == "" {
+		return nil, fmt.Errorf("%s parameter has no name", parameter.In)
+	}
+
+	g := dg.newGenerator()
+	constraints, err := g.parseFieldConstraints(field.Tag)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := g.generateSchemaRefFor(nil, field.Type, parameter.Name, field.Tag, constraints)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("%s parameter %q has no schema", parameter.In, parameter.Name)
+	}
+	g.moveComponents(dg.doc.Components.Schemas)
+	parameter.Schema = ref
+	if constraints != nil && constraints.required {
+		parameter.Required = true
+	}
+	return parameter, nil
+}
+
+// bodySchemaRef returns the schema of a request or response body.
+// Named struct types, and those of the elements of slices and maps,
+// are defined in the components and referenced.
+func (dg *DocumentGenerator) bodySchemaRef(t reflect.Type) (*openapi3.SchemaRef, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8:
+		items, err := dg.bodySchemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := openapi3.NewArraySchema()
+		schema.Items = items
+		return openapi3.NewSchemaRef("", schema), nil
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		additionalProperties, err := dg.bodySchemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := openapi3.NewObjectSchema()
+		schema.AdditionalProperties = additionalProperties
+		return openapi3.NewSchemaRef("", schema), nil
+	}
+
+	named := isNamedStruct(t)
+	if name := dg.components[t]; named && name != "" {
+		return openapi3.NewSchemaRef("#/components/schemas/"+name, nil), nil
+	}
+
+	g := dg.newGenerator()
+	ref, err := g.GenerateSchemaRef(t)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("type %s has no schema", t)
+	}
+	if named {
+		if ref, err = g.componentRef(t, ref.Value); err != nil {
+			return nil, err
+		}
+		dg.components[t] = strings.TrimPrefix(ref.Ref, "#/components/schemas/")
+		ref = openapi3.NewSchemaRef(ref.Ref, nil)
+	}
+	g.moveComponents(dg.doc.Components.Schemas)
+	return ref, nil
+}
+
+// newGenerator returns a generator sharing the component names of the document.
+func (dg *DocumentGenerator) newGenerator() *Generator {
+	g := NewGenerator(dg.opts...)
+	g.componentTypes = dg.componentTypes
+	return g
+}
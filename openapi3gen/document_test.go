@@ -0,0 +1,172 @@
+package openapi3gen_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/stretchr/testify/require"
+)
+
+type Pet struct {
+	ID   int64  `json:"id" openapi:"readOnly"`
+	Name string `json:"name" openapi:"minLength=1"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+type GetPetRequest struct {
+	ID        int64  `path:"petId"`
+	RequestID string `header:"X-Request-ID" openapi:"format=uuid"`
+}
+
+type ListPetsRequest struct {
+	Limit int32  `query:"limit" openapi:"minimum=1,maximum=100"`
+	Tag   string `query:"tag" openapi:"required"`
+}
+
+type CreatePetRequest struct {
+	Session string `cookie:"session"`
+	Pet     Pet    `body:""`
+}
+
+func TestDocumentGenerator(t *testing.T) {
+	dg := openapi3gen.NewDocumentGenerator(&openapi3.Info{Title: "Pets", Version: "1.0.0"})
+
+	err := dg.AddOperation(http.MethodGet, "/pets/{petId}", openapi3gen.Operation{
+		OperationID: "getPet",
+		Request:     GetPetRequest{},
+		Responses: map[int]interface{}{
+			http.StatusOK:       &Pet{},
+			http.StatusNotFound: nil,
+			0:                   Error{},
+		},
+	})
+	require.NoError(t, err)
+	err = dg.AddOperation(http.MethodGet, "/pets", openapi3gen.Operation{
+		OperationID: "listPets",
+		Request:     &ListPetsRequest{},
+		Responses: map[int]interface{}{
+			http.StatusOK: []Pet{},
+		},
+	})
+	require.NoError(t, err)
+	err = dg.AddOperation(http.MethodPost, "/pets", openapi3gen.Operation{
+		OperationID: "createPet",
+		Request:     CreatePetRequest{},
+		Responses: map[int]interface{}{
+			http.StatusCreated: Pet{},
+		},
+	})
+	require.NoError(t, err)
+
+	doc, err := dg.Document(context.Background())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "openapi": "3.0.3",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Error": {
+        "type": "object",
+        "properties": {"message": {"type": "string"}}
+      },
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "integer", "format": "int64", "readOnly": true},
+          "name": {"type": "string", "minLength": 1}
+        }
+      }
+    }
+  },
+  "paths": {
+    "/pets": {
+      "get": {
+        "operationId": "listPets",
+        "parameters": [
+          {"in": "query", "name": "limit", "schema": {"type": "integer", "format": "int32", "minimum": 1, "maximum": 100}},
+          {"in": "query", "name": "tag", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {"application/json": {"schema": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}}
+          }
+        }
+      },
+      "post": {
+        "operationId": "createPet",
+        "parameters": [
+          {"in": "cookie", "name": "session", "schema": {"type": "string"}}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+        },
+        "responses": {
+          "201": {
+            "description": "Created",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+          }
+        }
+      }
+    },
+    "/pets/{petId}": {
+      "get": {
+        "operationId": "getPet",
+        "parameters": [
+          {"in": "path", "name": "petId", "required": true, "schema": {"type": "integer", "format": "int64"}},
+          {"in": "header", "name": "X-Request-ID", "schema": {"type": "string", "format": "uuid"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}
+          },
+          "404": {"description": "Not Found"},
+          "default": {
+            "description": "Default response",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+          }
+        }
+      }
+    }
+  }
+}`, string(data))
+}
+
+func TestDocumentGeneratorInvalidDocument(t *testing.T) {
+	dg := openapi3gen.NewDocumentGenerator(&openapi3.Info{Title: "Pets", Version: "1.0.0"})
+	err := dg.AddOperation(http.MethodGet, "/pets/{petId}", openapi3gen.Operation{
+		Responses: map[int]interface{}{http.StatusOK: Pet{}},
+	})
+	require.NoError(t, err)
+
+	_, err = dg.Document(context.Background())
+	require.Error(t, err)
+}
+
+func TestDocumentGeneratorComponentNameCollision(t *testing.T) {
+	dg := openapi3gen.NewDocumentGenerator(&openapi3.Info{Title: "Pets", Version: "1.0.0"})
+	err := dg.AddOperation(http.MethodGet, "/pets", openapi3gen.Operation{
+		Responses: map[int]interface{}{http.StatusOK: Pet{}},
+	})
+	require.NoError(t, err)
+
+	type Pet struct {
+		Name string `json:"name"`
+	}
+	err = dg.AddOperation(http.MethodPost, "/pets", openapi3gen.Operation{
+		Responses: map[int]interface{}{http.StatusCreated: Pet{}},
+	})
+	require.EqualError(t, err, `operation POST /pets: response 201: component name "Pet" is used by both github.com/getkin/kin-openapi/openapi3gen_test.Pet and github.com/getkin/kin-openapi/openapi3gen_test.Pet`)
+}
@@ -0,0 +1,136 @@
+package openapi3gen
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/jsoninfo"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// implementations holds the concrete types registered for an interface type.
+type implementations struct {
+	propertyName string
+	types        map[string]reflect.Type
+}
+
+// generateOneOf makes schema a oneOf of the registered implementations of an interface.
+func (g *Generator) generateOneOf(parents []*jsoninfo.TypeInfo, impls *implementations, schema *openapi3.Schema) error {
+	values := make([]string, 0, len(impls.types))
+	for value := range impls.types {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	schema.Discriminator = &openapi3.Discriminator{
+		PropertyName: impls.propertyName,
+		Mapping:      make(map[string]string, len(values)),
+	}
+	for _, value := range values {
+		t := impls.types[value]
+		ref, err := g.generateComponentRefFor(parents, t)
+		if err != nil {
+			return err
+		}
+		schema.OneOf = append(schema.OneOf, ref)
+		schema.Discriminator.Mapping[value] = ref.Ref
+	}
+	return nil
+}
+
+// generateBaseRefs returns the allOf bases of a struct, when embedded structs are generated as such.
+func (g *Generator) generateBaseRefs(parents []*jsoninfo.TypeInfo, t reflect.Type) (openapi3.SchemaRefs, error) {
+	if !g.opts.embeddedStructsAsAllOf {
+		return nil, nil
+	}
+	var refs openapi3.SchemaRefs
+	for i := 0; i < t.NumField(); i++ {
+		if f := t.Field(i); g.isBase(f) {
+			ref, err := g.generateComponentRefFor(parents, f.Type)
+			if err != nil {
+				return nil, err
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs, nil
+}
+
+// generateComponentRefFor generates the schema of a named type as a component and references it.
+func (g *Generator) generateComponentRefFor(parents []*jsoninfo.TypeInfo, t reflect.Type) (*openapi3.SchemaRef, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	ref, err := g.generateSchemaRefFor(parents, t, t.Name(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("type %s has no schema", t)
+	}
+	if strings.HasPrefix(ref.Ref, "#/components/schemas/") {
+		return ref, nil
+	}
+	if ref, err = g.componentRef(t, ref.Value); err != nil {
+		return nil, err
+	}
+	g.SchemaRefs[ref]++
+	return ref, nil
+}
+
+// embeddingOf tells whether a field reached through embedded structs is flattened
+// into the struct t. When it is not because one of the embedded structs has a
+// JSON name, the index of that embedded struct is returned.
+func (g *Generator) embeddingOf(t reflect.Type, index []int) ([]int, bool) {
+	for depth := 0; depth < len(index)-1; depth++ {
+		f := t.Field(index[depth])
+		if depth == 0 && g.isBase(f) {
+			return nil, false
+		}
+		if name, _ := jsonTagName(f); name != "" {
+			return index[:depth+1], false
+		}
+		t = f.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	}
+	return nil, true
+}
+
+// isBase tells whether an embedded struct is generated as an allOf base.
+func (g *Generator) isBase(f reflect.StructField) bool {
+	if !g.opts.embeddedStructsAsAllOf || !f.Anonymous || f.Tag.Get("json") == "-" {
+		return false
+	}
+	if name, _ := jsonTagName(f); name != "" {
+		return false
+	}
+	t := f.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isNamedStruct(t)
+}
+
+// jsonTagName returns the name set by the JSON tag of a field and whether it has the omitempty option.
+func jsonTagName(f reflect.StructField) (string, bool) {
+	parts := strings.Split(f.Tag.Get("json"), ",")
+	for _, part := range parts[1:] {
+		if part == "omitempty" {
+			return parts[0], true
+		}
+	}
+	return parts[0], false
+}
+
+func containsIndex(indexes [][]int, index []int) bool {
+	for _, other := range indexes {
+		if reflect.DeepEqual(other, index) {
+			return true
+		}
+	}
+	return false
+}
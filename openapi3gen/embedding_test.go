@@ -0,0 +1,178 @@
+package openapi3gen_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/stretchr/testify/require"
+)
+
+type Animal interface {
+	Sound() string
+}
+
+type Cat struct {
+	Kind  string `json:"kind"`
+	Lives int    `json:"lives"`
+}
+
+func (Cat) Sound() string { return "meow" }
+
+type Dog struct {
+	Kind    string   `json:"kind"`
+	Friends []Animal `json:"friends,omitempty"`
+}
+
+func (Dog) Sound() string { return "woof" }
+
+var animalImplementations = openapi3gen.RegisterImplementations(reflect.TypeOf((*Animal)(nil)).Elem(), "kind", map[string]interface{}{
+	"dog": Dog{},
+	"cat": &Cat{},
+})
+
+func TestInterfaceImplementations(t *testing.T) {
+	type Owner struct {
+		Animal Animal `json:"animal"`
+	}
+
+	schemas := make(openapi3.Schemas)
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Owner{}, schemas, animalImplementations)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schemaRef)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "animal": {"$ref": "#/components/schemas/Animal"}
+  }
+}`, string(data))
+
+	data, err = json.Marshal(schemas)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "Animal": {
+    "oneOf": [
+      {"$ref": "#/components/schemas/Cat"},
+      {"$ref": "#/components/schemas/Dog"}
+    ],
+    "discriminator": {
+      "propertyName": "kind",
+      "mapping": {
+        "cat": "#/components/schemas/Cat",
+        "dog": "#/components/schemas/Dog"
+      }
+    }
+  },
+  "Cat": {
+    "type": "object",
+    "properties": {
+      "kind": {"type": "string"},
+      "lives": {"type": "integer"}
+    }
+  },
+  "Dog": {
+    "type": "object",
+    "properties": {
+      "kind": {"type": "string"},
+      "friends": {"type": "array", "items": {"$ref": "#/components/schemas/Animal"}}
+    }
+  }
+}`, string(data))
+}
+
+func TestInterfaceImplementationsDocument(t *testing.T) {
+	dg := openapi3gen.NewDocumentGenerator(&openapi3.Info{Title: "Animals", Version: "1.0.0"}, animalImplementations)
+	err := dg.AddOperation(http.MethodGet, "/animals", openapi3gen.Operation{
+		Responses: map[int]interface{}{http.StatusOK: []Animal{}},
+	})
+	require.NoError(t, err)
+
+	doc, err := dg.Document(context.Background())
+	require.NoError(t, err)
+	require.Contains(t, doc.Components.Schemas, "Animal")
+
+	animals := doc.Paths["/animals"].Get.Responses.Get(http.StatusOK).Value.Content.Get("application/json").Schema.Value
+	err = animals.VisitJSON([]interface{}{
+		map[string]interface{}{"kind": "cat", "lives": 9.0},
+	})
+	require.NoError(t, err)
+	err = animals.VisitJSON([]interface{}{
+		map[string]interface{}{"kind": "cat", "lives": "nine"},
+	})
+	require.Error(t, err)
+}
+
+type Base struct {
+	ID string `json:"id"`
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	type Flattened struct {
+		Base
+		Name string `json:"name"`
+	}
+	type Named struct {
+		Base `json:"base"`
+		Name string `json:"name"`
+	}
+	flattened := &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: "object",
+		Properties: openapi3.Schemas{
+			"id":   {Value: &openapi3.Schema{Type: "string"}},
+			"name": {Value: &openapi3.Schema{Type: "string"}},
+		},
+	}}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Flattened{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, flattened, schemaRef)
+
+	schemaRef, err = openapi3gen.NewSchemaRefForValue(&Flattened{}, nil, openapi3gen.UseAllExportedFields())
+	require.NoError(t, err)
+	require.Equal(t, flattened, schemaRef)
+
+	schemaRef, err = openapi3gen.NewSchemaRefForValue(&Named{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, &openapi3.SchemaRef{Value: &openapi3.Schema{
+		Type: "object",
+		Properties: openapi3.Schemas{
+			"base": {Value: &openapi3.Schema{
+				Type:       "object",
+				Properties: openapi3.Schemas{"id": {Value: &openapi3.Schema{Type: "string"}}},
+			}},
+			"name": {Value: &openapi3.Schema{Type: "string"}},
+		},
+	}}, schemaRef)
+}
+
+func TestEmbeddedStructsAsAllOf(t *testing.T) {
+	type Derived struct {
+		*Base
+		Name string `json:"name"`
+	}
+
+	schemas := make(openapi3.Schemas)
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Derived{}, schemas, openapi3gen.EmbeddedStructsAsAllOf())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schemaRef)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "allOf": [
+    {"$ref": "#/components/schemas/Base"},
+    {"type": "object", "properties": {"name": {"type": "string"}}}
+  ]
+}`, string(data))
+
+	data, err = json.Marshal(schemas)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "Base": {"type": "object", "properties": {"id": {"type": "string"}}}
+}`, string(data))
+}
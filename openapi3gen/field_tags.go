@@ -0,0 +1,309 @@
+package openapi3gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// fieldConstraints holds the schema constraints declared by
+// the "openapi" (and optionally "validate") tags of a struct field.
+type fieldConstraints struct {
+	required bool
+	apply    []func(schema *openapi3.Schema) error
+}
+
+// parseFieldConstraints reads the constraints from the tags of a struct field.
+// It returns nil if the field declares none.
+//
+// The "openapi" tag is a comma-separated list of key=value pairs and flags:
+//
+//	description, example, enum (values separated by "|"), minimum, maximum,
+//	minLength, maxLength, minItems, maxItems, pattern, format,
+//	readOnly, writeOnly, deprecated, nullable and required.
+//
+// A literal comma within a value is written as `\\,` in the tag.
+func (g *Generator) parseFieldConstraints(tag reflect.StructTag) (*fieldConstraints, error) {
+	c := &fieldConstraints{}
+	if value, ok := tag.Lookup("openapi"); ok {
+		if err := c.parseOpenAPITag(value); err != nil {
+			return nil, fmt.Errorf("invalid openapi tag %q: %w", value, err)
+		}
+	}
+	if g.opts.useValidateTags {
+		if value, ok := tag.Lookup("validate"); ok {
+			if err := c.parseValidateTag(value); err != nil {
+				return nil, fmt.Errorf("invalid validate tag %q: %w", value, err)
+			}
+		}
+	}
+	if !c.required && len(c.apply) == 0 {
+		return nil, nil
+	}
+	return c, nil
+}
+
+func (c *fieldConstraints) applyTo(schema *openapi3.Schema) error {
+	for _, apply := range c.apply {
+		if err := apply(schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *fieldConstraints) parseOpenAPITag(value string) error {
+	for _, item := range splitTag(value) {
+		if item == "" {
+			continue
+		}
+		key, arg, hasArg := cut(item, "=")
+		switch key {
+		case "required":
+			c.required = true
+		case "readOnly":
+			c.set(func(schema *openapi3.Schema) { schema.ReadOnly = true })
+		case "writeOnly":
+			c.set(func(schema *openapi3.Schema) { schema.WriteOnly = true })
+		case "deprecated":
+			c.set(func(schema *openapi3.Schema) { schema.Deprecated = true })
+		case "nullable":
+			c.set(func(schema *openapi3.Schema) { schema.Nullable = true })
+		default:
+			if !hasArg {
+				return fmt.Errorf("unsupported flag %q", key)
+			}
+			if err := c.parseOpenAPIKey(key, arg); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *fieldConstraints) parseOpenAPIKey(key, arg string) error {
+	switch key {
+	case "description":
+		c.set(func(schema *openapi3.Schema) { schema.Description = arg })
+	case "pattern":
+		c.set(func(schema *openapi3.Schema) { schema.Pattern = arg })
+	case "format":
+		c.set(func(schema *openapi3.Schema) { schema.Format = arg })
+	case "example":
+		c.apply = append(c.apply, func(schema *openapi3.Schema) error {
+			v, err := parseTagValue(schema, arg)
+			if err != nil {
+				return fmt.Errorf("invalid example %q: %w", arg, err)
+			}
+			schema.Example = v
+			return nil
+		})
+	case "enum":
+		c.apply = append(c.apply, func(schema *openapi3.Schema) error {
+			values := strings.Split(arg, "|")
+			schema.Enum = make([]interface{}, 0, len(values))
+			for _, value := range values {
+				v, err := parseTagValue(schema, value)
+				if err != nil {
+					return fmt.Errorf("invalid enum value %q: %w", value, err)
+				}
+				schema.Enum = append(schema.Enum, v)
+			}
+			return nil
+		})
+	case "minimum", "maximum":
+		f, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, arg, err)
+		}
+		if key == "minimum" {
+			c.set(func(schema *openapi3.Schema) { schema.Min = &f })
+		} else {
+			c.set(func(schema *openapi3.Schema) { schema.Max = &f })
+		}
+	case "minLength", "maxLength", "minItems", "maxItems":
+		n, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", key, arg, err)
+		}
+		switch key {
+		case "minLength":
+			c.set(func(schema *openapi3.Schema) { schema.MinLength = n })
+		case "maxLength":
+			c.set(func(schema *openapi3.Schema) { schema.MaxLength = &n })
+		case "minItems":
+			c.set(func(schema *openapi3.Schema) { schema.MinItems = n })
+		case "maxItems":
+			c.set(func(schema *openapi3.Schema) { schema.MaxItems = &n })
+		}
+	default:
+		return fmt.Errorf("unsupported key %q", key)
+	}
+	return nil
+}
+
+// parseValidateTag maps the go-playground/validator rules that have an
+// OpenAPI equivalent. Other rules, and rules following "dive" which
+// apply to the elements of a collection, are ignored.
+func (c *fieldConstraints) parseValidateTag(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		key, arg, _ := cut(item, "=")
+		switch key {
+		case "dive":
+			return nil
+		case "required":
+			c.required = true
+		case "email":
+			c.set(func(schema *openapi3.Schema) { schema.Format = "email" })
+		case "url", "uri":
+			c.set(func(schema *openapi3.Schema) { schema.Format = "uri" })
+		case "uuid", "uuid4":
+			c.set(func(schema *openapi3.Schema) { schema.Format = "uuid" })
+		case "ipv4", "ipv6", "hostname":
+			format := key
+			c.set(func(schema *openapi3.Schema) { schema.Format = format })
+		case "oneof":
+			c.apply = append(c.apply, func(schema *openapi3.Schema) error {
+				values := strings.Fields(arg)
+				schema.Enum = make([]interface{}, 0, len(values))
+				for _, value := range values {
+					v, err := parseTagValue(schema, strings.Trim(value, "'"))
+					if err != nil {
+						return fmt.Errorf("invalid oneof value %q: %w", value, err)
+					}
+					schema.Enum = append(schema.Enum, v)
+				}
+				return nil
+			})
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			f, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s %q: %w", key, arg, err)
+			}
+			rule := key
+			c.set(func(schema *openapi3.Schema) { applyValidateBound(schema, rule, f) })
+		}
+	}
+	return nil
+}
+
+// applyValidateBound applies a go-playground/validator bound to the
+// value of a number, or to the length of a string, array or map.
+func applyValidateBound(schema *openapi3.Schema, rule string, f float64) {
+	switch schema.Type {
+	case "integer", "number":
+		switch rule {
+		case "min", "gte":
+			schema.Min, schema.ExclusiveMin = &f, false
+		case "gt":
+			schema.Min, schema.ExclusiveMin = &f, true
+		case "max", "lte":
+			schema.Max, schema.ExclusiveMax = &f, false
+		case "lt":
+			schema.Max, schema.ExclusiveMax = &f, true
+		case "len":
+			schema.Enum = []interface{}{f}
+		}
+		return
+	}
+
+	var min, max *uint64
+	n := uint64(f)
+	switch rule {
+	case "min", "gte":
+		min = &n
+	case "gt":
+		n++
+		min = &n
+	case "max", "lte":
+		max = &n
+	case "lt":
+		if n > 0 {
+			n--
+		}
+		max = &n
+	case "len":
+		min, max = &n, &n
+	}
+	switch schema.Type {
+	case "string":
+		if min != nil {
+			schema.MinLength = *min
+		}
+		if max != nil {
+			schema.MaxLength = max
+		}
+	case "array":
+		if min != nil {
+			schema.MinItems = *min
+		}
+		if max != nil {
+			schema.MaxItems = max
+		}
+	case "object":
+		if min != nil {
+			schema.MinProps = *min
+		}
+		if max != nil {
+			schema.MaxProps = max
+		}
+	}
+}
+
+func (c *fieldConstraints) set(f func(schema *openapi3.Schema)) {
+	c.apply = append(c.apply, func(schema *openapi3.Schema) error {
+		f(schema)
+		return nil
+	})
+}
+
+// parseTagValue converts a value written in a tag to the type of the schema.
+func parseTagValue(schema *openapi3.Schema, value string) (interface{}, error) {
+	switch schema.Type {
+	case "integer", "number":
+		return strconv.ParseFloat(value, 64)
+	case "boolean":
+		return strconv.ParseBool(value)
+	case "array", "object":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+// splitTag splits a tag value on commas, except those escaped with a backslash.
+func splitTag(value string) []string {
+	var items []string
+	var item strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch ch := value[i]; {
+		case ch == '\\' && i+1 < len(value) && value[i+1] == ',':
+			item.WriteByte(',')
+			i++
+		case ch == ',':
+			items = append(items, strings.TrimSpace(item.String()))
+			item.Reset()
+		default:
+			item.WriteByte(ch)
+		}
+	}
+	if s := strings.TrimSpace(item.String()); s != "" || len(items) > 0 {
+		items = append(items, s)
+	}
+	return items
+}
+
+func cut(s, sep string) (before, after string, found bool) {
+	if i := strings.Index(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
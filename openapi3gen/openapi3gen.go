@@ -37,9 +37,16 @@ type Option func(*generatorOpt)
 type SchemaCustomizerFn func(name string, t reflect.Type, tag reflect.StructTag, schema *openapi3.Schema) error
 
 type generatorOpt struct {
-	useAllExportedFields bool
-	throwErrorOnCycle    bool
-	schemaCustomizer     SchemaCustomizerFn
+	useAllExportedFields      bool
+	throwErrorOnCycle         bool
+	useValidateTags           bool
+	requireNonOmitEmptyFields bool
+	embeddedStructsAsAllOf    bool
+	hoistNamedStructs         bool
+	componentName             ComponentNameFn
+	typeSchemas               map[reflect.Type]*openapi3.Schema
+	implementations           map[reflect.Type]*implementations
+	schemaCustomizer          SchemaCustomizerFn
 }
 
 // UseAllExportedFields changes the default behavior of only
@@ -54,6 +61,77 @@ func ThrowErrorOnCycle() Option {
 	return func(x *generatorOpt) { x.throwErrorOnCycle = true }
 }
 
+// UseValidateTags makes the generator honor the go-playground/validator
+// "validate" struct tags that have an OpenAPI equivalent, such as
+// required, min, max, len, oneof, email or uuid, in addition to "openapi" tags.
+func UseValidateTags() Option {
+	return func(x *generatorOpt) { x.useValidateTags = true }
+}
+
+// RequireNonOmitEmptyFields marks as required the struct fields
+// whose JSON tag does not have the omitempty option.
+func RequireNonOmitEmptyFields() Option {
+	return func(x *generatorOpt) { x.requireNonOmitEmptyFields = true }
+}
+
+// EmbeddedStructsAsAllOf changes the default behavior of flattening
+// the fields of embedded structs into the embedding struct: the schema of
+// the embedding struct becomes an allOf of the embedded structs, defined
+// in the components, and of its own fields.
+func EmbeddedStructsAsAllOf() Option {
+	return func(x *generatorOpt) { x.embeddedStructsAsAllOf = true }
+}
+
+// RegisterImplementations declares the concrete types of an interface type,
+// keyed by the value their discriminator property takes. The schema of the
+// interface is then a oneOf of its implementations with a discriminator,
+// all defined in the components. For example:
+//
+//	RegisterImplementations(reflect.TypeOf((*Pet)(nil)).Elem(), "kind", map[string]interface{}{
+//		"cat": Cat{},
+//		"dog": Dog{},
+//	})
+func RegisterImplementations(iface reflect.Type, propertyName string, values map[string]interface{}) Option {
+	return func(x *generatorOpt) {
+		if x.implementations == nil {
+			x.implementations = make(map[reflect.Type]*implementations)
+		}
+		impls := &implementations{
+			propertyName: propertyName,
+			types:        make(map[string]reflect.Type, len(values)),
+		}
+		for value, v := range values {
+			impls.types[value] = reflect.TypeOf(v)
+		}
+		x.implementations[iface] = impls
+	}
+}
+
+// HoistNamedStructs changes the default behavior of inlining the schemas
+// of struct types: every named struct type, except the root one, is defined
+// in the components and referenced.
+func HoistNamedStructs() Option {
+	return func(x *generatorOpt) { x.hoistNamedStructs = true }
+}
+
+// ComponentName sets how the types defined in the components are named.
+// The default is ShortComponentName.
+func ComponentName(fn ComponentNameFn) Option {
+	return func(x *generatorOpt) { x.componentName = fn }
+}
+
+// TypeSchema sets the schema generated for a type, in place of the schema
+// of its Go structure. It takes precedence over the schemas of well-known
+// types such as net.IP, big.Int or time.Duration.
+func TypeSchema(t reflect.Type, schema *openapi3.Schema) Option {
+	return func(x *generatorOpt) {
+		if x.typeSchemas == nil {
+			x.typeSchemas = make(map[reflect.Type]*openapi3.Schema)
+		}
+		x.typeSchemas[t] = schema
+	}
+}
+
 // SchemaCustomizer allows customization of the schema that is generated
 // for a field, for example to support an additional tagging scheme
 func SchemaCustomizer(sc SchemaCustomizerFn) Option {
@@ -76,8 +154,15 @@ type Generator struct {
 	// An OpenAPI identifier has been assigned to each.
 	SchemaRefs map[*openapi3.SchemaRef]int
 
-	// componentSchemaRefs is a set of schemas that must be defined in the components to avoid cycles
-	componentSchemaRefs map[string]struct{}
+	// cycleRefs are the references to types found in a cycle,
+	// whose schema is set once the generation of the type is over
+	cycleRefs map[reflect.Type][]*openapi3.SchemaRef
+
+	// componentSchemas are the schemas that must be defined in the components
+	componentSchemas map[string]*openapi3.Schema
+
+	// componentTypes maps the names of the components to the type they define
+	componentTypes map[string]reflect.Type
 }
 
 func NewGenerator(opts ...Option) *Generator {
@@ -86,16 +171,18 @@ func NewGenerator(opts ...Option) *Generator {
 		f(gOpt)
 	}
 	return &Generator{
-		Types:               make(map[reflect.Type]*openapi3.SchemaRef),
-		SchemaRefs:          make(map[*openapi3.SchemaRef]int),
-		componentSchemaRefs: make(map[string]struct{}),
-		opts:                *gOpt,
+		Types:            make(map[reflect.Type]*openapi3.SchemaRef),
+		SchemaRefs:       make(map[*openapi3.SchemaRef]int),
+		cycleRefs:        make(map[reflect.Type][]*openapi3.SchemaRef),
+		componentSchemas: make(map[string]*openapi3.Schema),
+		componentTypes:   make(map[string]reflect.Type),
+		opts:             *gOpt,
 	}
 }
 
 func (g *Generator) GenerateSchemaRef(t reflect.Type) (*openapi3.SchemaRef, error) {
 	//check generatorOpt consistency here
-	return g.generateSchemaRefFor(nil, t, "_root", "")
+	return g.generateSchemaRefFor(nil, t, "_root", "", nil)
 }
 
 // NewSchemaRefForValue uses reflection on the given value to produce a SchemaRef, and updates a supplied map with any dependent component schemas if they lead to cycles
@@ -104,27 +191,37 @@ func (g *Generator) NewSchemaRefForValue(value interface{}, schemas openapi3.Sch
 	if err != nil {
 		return nil, err
 	}
-	for ref := range g.SchemaRefs {
-		if _, ok := g.componentSchemaRefs[ref.Ref]; ok && schemas != nil {
-			schemas[ref.Ref] = &openapi3.SchemaRef{
-				Value: ref.Value,
+	g.moveComponents(schemas)
+	return ref, nil
+}
+
+// moveComponents adds the component schemas to schemas,
+// and turns the generated schema references into either references or values.
+func (g *Generator) moveComponents(schemas openapi3.Schemas) {
+	if schemas != nil {
+		for name, schema := range g.componentSchemas {
+			schemas[name] = &openapi3.SchemaRef{
+				Value: schema,
 			}
 		}
+	}
+	for ref := range g.SchemaRefs {
 		if strings.HasPrefix(ref.Ref, "#/components/schemas/") {
 			ref.Value = nil
 		} else {
 			ref.Ref = ""
 		}
 	}
-	return ref, nil
 }
 
-func (g *Generator) generateSchemaRefFor(parents []*jsoninfo.TypeInfo, t reflect.Type, name string, tag reflect.StructTag) (*openapi3.SchemaRef, error) {
-	if ref := g.Types[t]; ref != nil && g.opts.schemaCustomizer == nil {
+// generateSchemaRefFor generates the schema of a type, or reuses it if it was already generated.
+// Schemas carrying the constraints of a struct field are specific to that field and are never reused.
+func (g *Generator) generateSchemaRefFor(parents []*jsoninfo.TypeInfo, t reflect.Type, name string, tag reflect.StructTag, constraints *fieldConstraints) (*openapi3.SchemaRef, error) {
+	if ref := g.Types[t]; ref != nil && g.opts.schemaCustomizer == nil && constraints == nil {
 		g.SchemaRefs[ref]++
 		return ref, nil
 	}
-	ref, err := g.generateWithoutSaving(parents, t, name, tag)
+	ref, err := g.generateWithoutSaving(parents, t, name, tag, constraints)
 	if _, ok := err.(*ExcludeSchemaSentinel); ok {
 		// This schema should not be included in the final output
 		return nil, nil
@@ -133,7 +230,9 @@ func (g *Generator) generateSchemaRefFor(parents []*jsoninfo.TypeInfo, t reflect
 		return nil, err
 	}
 	if ref != nil {
-		g.Types[t] = ref
+		if constraints == nil {
+			g.Types[t] = ref
+		}
 		g.SchemaRefs[ref]++
 	}
 	return ref, nil
@@ -152,7 +251,7 @@ func getStructField(t reflect.Type, fieldInfo jsoninfo.FieldInfo) reflect.Struct
 	return ff
 }
 
-func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflect.Type, name string, tag reflect.StructTag) (*openapi3.SchemaRef, error) {
+func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflect.Type, name string, tag reflect.StructTag, constraints *fieldConstraints) (*openapi3.SchemaRef, error) {
 	typeInfo := jsoninfo.GetTypeInfo(t)
 	for _, parent := range parents {
 		if parent == typeInfo {
@@ -173,7 +272,7 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 		_, a := t.FieldByName("Ref")
 		v, b := t.FieldByName("Value")
 		if a && b {
-			vs, err := g.generateSchemaRefFor(parents, v.Type, name, tag)
+			vs, err := g.generateSchemaRefFor(parents, v.Type, name, tag, constraints)
 			if err != nil {
 				if _, ok := err.(*CycleError); ok && !g.opts.throwErrorOnCycle {
 					g.SchemaRefs[vs]++
@@ -195,8 +294,17 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 	}
 
 	schema := &openapi3.Schema{}
+	component := false
+
+	kind := t.Kind()
+	mapped := g.mappedSchema(t)
+	if mapped != nil {
+		schema = mapped
+		// Do not generate the schema from the Go structure
+		kind = reflect.Invalid
+	}
 
-	switch t.Kind() {
+	switch kind {
 	case reflect.Func, reflect.Chan:
 		return nil, nil // ignore
 
@@ -258,10 +366,12 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 			schema.Format = "byte"
 		} else {
 			schema.Type = "array"
-			items, err := g.generateSchemaRefFor(parents, t.Elem(), name, tag)
+			items, err := g.generateSchemaRefFor(parents, t.Elem(), name, tag, nil)
 			if err != nil {
 				if _, ok := err.(*CycleError); ok && !g.opts.throwErrorOnCycle {
-					items = g.generateCycleSchemaRef(t.Elem(), schema)
+					if items, err = g.generateCycleSchemaRef(t.Elem()); err != nil {
+						return nil, err
+					}
 				} else {
 					return nil, err
 				}
@@ -274,10 +384,12 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 
 	case reflect.Map:
 		schema.Type = "object"
-		additionalProperties, err := g.generateSchemaRefFor(parents, t.Elem(), name, tag)
+		additionalProperties, err := g.generateSchemaRefFor(parents, t.Elem(), name, tag, nil)
 		if err != nil {
 			if _, ok := err.(*CycleError); ok && !g.opts.throwErrorOnCycle {
-				additionalProperties = g.generateCycleSchemaRef(t.Elem(), schema)
+				if additionalProperties, err = g.generateCycleSchemaRef(t.Elem()); err != nil {
+					return nil, err
+				}
 			} else {
 				return nil, err
 			}
@@ -292,54 +404,47 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 			schema.Type = "string"
 			schema.Format = "date-time"
 		} else {
+			baseRefs, err := g.generateBaseRefs(parents, t)
+			if err != nil {
+				return nil, err
+			}
+
+			var namedEmbeds [][]int
 			for _, fieldInfo := range typeInfo.Fields {
+				// Fields of embedded structs are flattened unless the struct
+				// is a named property or an allOf base
+				if prefix, flattened := g.embeddingOf(t, fieldInfo.Index); !flattened {
+					if prefix != nil && !containsIndex(namedEmbeds, prefix) {
+						namedEmbeds = append(namedEmbeds, prefix)
+					}
+					continue
+				}
+
 				// Only fields with JSON tag are considered (by default)
 				if !fieldInfo.HasJSONTag && !g.opts.useAllExportedFields {
 					continue
 				}
 				// If asked, try to use yaml tag
 				fieldName, fType := fieldInfo.JSONName, fieldInfo.Type
+				ff := getStructField(t, fieldInfo)
 				if !fieldInfo.HasJSONTag && g.opts.useAllExportedFields {
-					// Handle anonymous fields/embedded structs
-					if t.Field(fieldInfo.Index[0]).Anonymous {
-						ref, err := g.generateSchemaRefFor(parents, fType, fieldName, tag)
-						if err != nil {
-							if _, ok := err.(*CycleError); ok && !g.opts.throwErrorOnCycle {
-								ref = g.generateCycleSchemaRef(fType, schema)
-							} else {
-								return nil, err
-							}
-						}
-						if ref != nil {
-							g.SchemaRefs[ref]++
-							schema.WithPropertyRef(fieldName, ref)
-						}
-					} else {
-						ff := getStructField(t, fieldInfo)
-						if tag, ok := ff.Tag.Lookup("yaml"); ok && tag != "-" {
-							fieldName, fType = tag, ff.Type
-						}
+					if tag, ok := ff.Tag.Lookup("yaml"); ok && tag != "-" {
+						fieldName, fType = tag, ff.Type
 					}
 				}
 
-				// extract the field tag if we have a customizer
-				var fieldTag reflect.StructTag
-				if g.opts.schemaCustomizer != nil {
-					ff := getStructField(t, fieldInfo)
-					fieldTag = ff.Tag
+				required := g.opts.requireNonOmitEmptyFields && fieldInfo.HasJSONTag && !fieldInfo.JSONOmitEmpty
+				if err := g.generateProperty(parents, schema, fieldName, fType, ff.Tag, required); err != nil {
+					return nil, err
 				}
+			}
 
-				ref, err := g.generateSchemaRefFor(parents, fType, fieldName, fieldTag)
-				if err != nil {
-					if _, ok := err.(*CycleError); ok && !g.opts.throwErrorOnCycle {
-						ref = g.generateCycleSchemaRef(fType, schema)
-					} else {
-						return nil, err
-					}
-				}
-				if ref != nil {
-					g.SchemaRefs[ref]++
-					schema.WithPropertyRef(fieldName, ref)
+			for _, index := range namedEmbeds {
+				ff := t.FieldByIndex(index)
+				fieldName, omitEmpty := jsonTagName(ff)
+				required := g.opts.requireNonOmitEmptyFields && !omitEmpty
+				if err := g.generateProperty(parents, schema, fieldName, ff.Type, ff.Tag, required); err != nil {
+					return nil, err
 				}
 			}
 
@@ -347,6 +452,28 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 			if schema.Properties != nil {
 				schema.Type = "object"
 			}
+
+			if len(baseRefs) != 0 {
+				own := *schema
+				*schema = openapi3.Schema{AllOf: baseRefs}
+				if own.Properties != nil {
+					schema.AllOf = append(schema.AllOf, openapi3.NewSchemaRef("", &own))
+				}
+			}
+		}
+
+	case reflect.Interface:
+		if impls := g.opts.implementations[t]; impls != nil {
+			if err := g.generateOneOf(parents, impls, schema); err != nil {
+				return nil, err
+			}
+			component = true
+		}
+	}
+
+	if constraints != nil {
+		if err := constraints.applyTo(schema); err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
 		}
 	}
 
@@ -356,32 +483,82 @@ func (g *Generator) generateWithoutSaving(parents []*jsoninfo.TypeInfo, t reflec
 		}
 	}
 
+	if refs, ok := g.cycleRefs[t]; ok {
+		// This type was found in a cycle: it must be defined in the components
+		delete(g.cycleRefs, t)
+		for _, ref := range refs {
+			ref.Value = schema
+		}
+		g.componentSchemas[strings.TrimPrefix(refs[0].Ref, "#/components/schemas/")] = schema
+	}
+
+	if g.opts.hoistNamedStructs && constraints == nil && mapped == nil && len(parents) > 1 && isNamedStruct(t) {
+		component = true
+	}
+	if component {
+		return g.componentRef(t, schema)
+	}
 	return openapi3.NewSchemaRef(t.Name(), schema), nil
 }
 
-func (g *Generator) generateCycleSchemaRef(t reflect.Type, schema *openapi3.Schema) *openapi3.SchemaRef {
-	var typeName string
+// generateProperty adds to schema the property held by a struct field.
+func (g *Generator) generateProperty(parents []*jsoninfo.TypeInfo, schema *openapi3.Schema, fieldName string, fType reflect.Type, fieldTag reflect.StructTag, required bool) error {
+	constraints, err := g.parseFieldConstraints(fieldTag)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", fieldName, err)
+	}
+
+	ref, err := g.generateSchemaRefFor(parents, fType, fieldName, fieldTag, constraints)
+	if err != nil {
+		if _, ok := err.(*CycleError); ok && !g.opts.throwErrorOnCycle {
+			if ref, err = g.generateCycleSchemaRef(fType); err != nil {
+				return err
+			}
+		} else {
+			return err
+		}
+	}
+	if ref != nil {
+		g.SchemaRefs[ref]++
+		schema.WithPropertyRef(fieldName, ref)
+		if required || (constraints != nil && constraints.required) {
+			schema.Required = append(schema.Required, fieldName)
+		}
+	}
+	return nil
+}
+
+func (g *Generator) generateCycleSchemaRef(t reflect.Type) (*openapi3.SchemaRef, error) {
 	switch t.Kind() {
 	case reflect.Ptr:
-		return g.generateCycleSchemaRef(t.Elem(), schema)
+		return g.generateCycleSchemaRef(t.Elem())
 	case reflect.Slice:
-		ref := g.generateCycleSchemaRef(t.Elem(), schema)
+		ref, err := g.generateCycleSchemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
 		sliceSchema := openapi3.NewSchema()
 		sliceSchema.Type = "array"
 		sliceSchema.Items = ref
-		return openapi3.NewSchemaRef("", sliceSchema)
+		return openapi3.NewSchemaRef("", sliceSchema), nil
 	case reflect.Map:
-		ref := g.generateCycleSchemaRef(t.Elem(), schema)
+		ref, err := g.generateCycleSchemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
 		mapSchema := openapi3.NewSchema()
 		mapSchema.Type = "object"
 		mapSchema.AdditionalProperties = ref
-		return openapi3.NewSchemaRef("", mapSchema)
-	default:
-		typeName = t.Name()
+		return openapi3.NewSchemaRef("", mapSchema), nil
 	}
 
-	g.componentSchemaRefs[typeName] = struct{}{}
-	return openapi3.NewSchemaRef(fmt.Sprintf("#/components/schemas/%s", typeName), schema)
+	name, err := g.componentName(t)
+	if err != nil {
+		return nil, err
+	}
+	ref := openapi3.NewSchemaRef("#/components/schemas/"+name, nil)
+	g.cycleRefs[t] = append(g.cycleRefs[t], ref)
+	return ref, nil
 }
 
 var RefSchemaRef = openapi3.NewSchemaRef("Ref",
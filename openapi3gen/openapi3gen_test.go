@@ -559,3 +559,128 @@ func ExampleNewSchemaRefForValue_recursive() {
 	//   "type": "object"
 	// }
 }
+
+func ExampleRequireNonOmitEmptyFields() {
+	type Pet struct {
+		Name string   `json:"name" openapi:"description=Name of the pet\\, unique,minLength=1,maxLength=20,example=Rex"`
+		Kind string   `json:"kind" openapi:"enum=cat|dog"`
+		Age  int      `json:"age,omitempty" openapi:"minimum=0,maximum=30,readOnly"`
+		Tags []string `json:"tags,omitempty" openapi:"maxItems=3,nullable,deprecated"`
+	}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Pet{}, nil, openapi3gen.RequireNonOmitEmptyFields())
+	if err != nil {
+		panic(err)
+	}
+
+	var data []byte
+	if data, err = json.MarshalIndent(schemaRef, "", "  "); err != nil {
+		panic(err)
+	}
+	fmt.Printf("schemaRef: %s\n", data)
+	// Output:
+	// schemaRef: {
+	//   "properties": {
+	//     "age": {
+	//       "maximum": 30,
+	//       "minimum": 0,
+	//       "readOnly": true,
+	//       "type": "integer"
+	//     },
+	//     "kind": {
+	//       "enum": [
+	//         "cat",
+	//         "dog"
+	//       ],
+	//       "type": "string"
+	//     },
+	//     "name": {
+	//       "description": "Name of the pet, unique",
+	//       "example": "Rex",
+	//       "maxLength": 20,
+	//       "minLength": 1,
+	//       "type": "string"
+	//     },
+	//     "tags": {
+	//       "deprecated": true,
+	//       "items": {
+	//         "type": "string"
+	//       },
+	//       "maxItems": 3,
+	//       "nullable": true,
+	//       "type": "array"
+	//     }
+	//   },
+	//   "required": [
+	//     "kind",
+	//     "name"
+	//   ],
+	//   "type": "object"
+	// }
+}
+
+func TestValidateTags(t *testing.T) {
+	type User struct {
+		Email string   `json:"email" validate:"required,email"`
+		Role  string   `json:"role" validate:"oneof=admin user"`
+		Age   int      `json:"age" validate:"gte=18,lt=130"`
+		Nick  string   `json:"nick" validate:"min=2,max=10"`
+		Tags  []string `json:"tags" validate:"max=5,dive,min=1"`
+	}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&User{}, nil)
+	require.NoError(t, err)
+	require.Empty(t, schemaRef.Value.Required)
+	require.Empty(t, schemaRef.Value.Properties["email"].Value.Format)
+
+	schemaRef, err = openapi3gen.NewSchemaRefForValue(&User{}, nil, openapi3gen.UseValidateTags())
+	require.NoError(t, err)
+	schema := schemaRef.Value
+	require.Equal(t, []string{"email"}, schema.Required)
+	require.Equal(t, "email", schema.Properties["email"].Value.Format)
+	require.Equal(t, []interface{}{"admin", "user"}, schema.Properties["role"].Value.Enum)
+
+	age := schema.Properties["age"].Value
+	require.Equal(t, float64(18), *age.Min)
+	require.False(t, age.ExclusiveMin)
+	require.Equal(t, float64(130), *age.Max)
+	require.True(t, age.ExclusiveMax)
+
+	nick := schema.Properties["nick"].Value
+	require.Equal(t, uint64(2), nick.MinLength)
+	require.Equal(t, uint64(10), *nick.MaxLength)
+
+	tags := schema.Properties["tags"].Value
+	require.Equal(t, uint64(5), *tags.MaxItems)
+	require.Zero(t, tags.Items.Value.MinLength)
+
+	err = schema.VisitJSON(map[string]interface{}{"email": "a@b.c", "age": 130.0})
+	require.Error(t, err)
+}
+
+func TestFieldTagsDoNotLeakIntoSharedTypes(t *testing.T) {
+	type Name string
+	type Pair struct {
+		First  Name `json:"first" openapi:"maxLength=5"`
+		Second Name `json:"second"`
+	}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Pair{}, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), *schemaRef.Value.Properties["first"].Value.MaxLength)
+	require.Nil(t, schemaRef.Value.Properties["second"].Value.MaxLength)
+}
+
+func TestInvalidFieldTags(t *testing.T) {
+	type Unknown struct {
+		Name string `json:"name" openapi:"colour=red"`
+	}
+	_, err := openapi3gen.NewSchemaRefForValue(&Unknown{}, nil)
+	require.EqualError(t, err, `field name: invalid openapi tag "colour=red": unsupported key "colour"`)
+
+	type BadExample struct {
+		Age int `json:"age" openapi:"example=old"`
+	}
+	_, err = openapi3gen.NewSchemaRefForValue(&BadExample{}, nil)
+	require.EqualError(t, err, `field age: invalid example "old": strconv.ParseFloat: parsing "old": invalid syntax`)
+}
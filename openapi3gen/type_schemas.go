@@ -0,0 +1,65 @@
+package openapi3gen
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// wellKnownTypes are the schemas of common standard library types
+// whose wire form differs from their Go structure.
+//
+// Types such as url.URL and sql.NullString are not among them: encoding/json
+// encodes them as objects of their fields, which is what their generated schemas describe.
+// Types wrapping them with a MarshalJSON method get the schemas of their wire form with TypeSchema.
+var wellKnownTypes = map[reflect.Type]*openapi3.Schema{
+	reflect.TypeOf(net.IP{}):         {Type: "string"},
+	reflect.TypeOf(time.Duration(0)): {Type: "integer", Format: "int64"},
+	reflect.TypeOf(big.Int{}):        {Type: "integer"},
+}
+
+// uuidPackages are the packages of common UUID types, matched by name
+// so as not to depend on them.
+var uuidPackages = map[string]struct{}{
+	"github.com/google/uuid":    {},
+	"github.com/gofrs/uuid":     {},
+	"github.com/satori/go.uuid": {},
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// mappedSchema returns a copy of the fixed schema of a type, either registered with
+// TypeSchema or well-known, or nil if its schema is generated from its Go structure.
+// Types that marshal to text, and not to JSON, are strings.
+func (g *Generator) mappedSchema(t reflect.Type) *openapi3.Schema {
+	schema := g.opts.typeSchemas[t]
+	if schema == nil {
+		schema = wellKnownTypes[t]
+	}
+	if schema == nil {
+		if _, ok := uuidPackages[t.PkgPath()]; ok && t.Name() == "UUID" {
+			schema = &openapi3.Schema{Type: "string", Format: "uuid"}
+		}
+	}
+	if schema == nil && t != timeType && implements(t, textMarshalerType) && !implements(t, jsonMarshalerType) {
+		schema = &openapi3.Schema{Type: "string"}
+	}
+	if schema == nil {
+		return nil
+	}
+	copied := *schema
+	return &copied
+}
+
+// implements tells whether values of a type, or pointers to them, implement an interface.
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PtrTo(t).Implements(iface)
+}
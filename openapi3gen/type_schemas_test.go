@@ -0,0 +1,123 @@
+package openapi3gen_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3gen"
+	"github.com/stretchr/testify/require"
+)
+
+// Version marshals to text, as in "1.2".
+type Version struct {
+	Major, Minor int
+}
+
+func (v Version) MarshalText() ([]byte, error) { return []byte("1.2"), nil }
+
+// Money marshals to text through a pointer receiver.
+type Money struct {
+	Units int64
+}
+
+func (m *Money) MarshalText() ([]byte, error) { return []byte("1.00"), nil }
+
+// Point marshals to both text and JSON: its JSON form prevails.
+type Point struct {
+	X int `json:"x"`
+}
+
+func (p Point) MarshalText() ([]byte, error) { return []byte("0"), nil }
+func (p Point) MarshalJSON() ([]byte, error) { return []byte(`{"x":0}`), nil }
+
+func TestWellKnownTypes(t *testing.T) {
+	type Record struct {
+		IP      net.IP        `json:"ip"`
+		Timeout time.Duration `json:"timeout"`
+		Balance *big.Int      `json:"balance"`
+		Version Version       `json:"version"`
+		Price   Money         `json:"price"`
+		Origin  Point         `json:"origin"`
+		Created time.Time     `json:"created"`
+	}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Record{}, nil, openapi3gen.HoistNamedStructs())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schemaRef)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "ip": {"type": "string"},
+    "timeout": {"type": "integer", "format": "int64"},
+    "balance": {"type": "integer"},
+    "version": {"type": "string"},
+    "price": {"type": "string"},
+    "origin": {"$ref": "#/components/schemas/Point"},
+    "created": {"type": "string", "format": "date-time"}
+  }
+}`, string(data))
+}
+
+func TestStructEncodedStandardTypes(t *testing.T) {
+	// sql.NullString and url.URL have no MarshalJSON method: their schemas are the ones of their fields
+	type Profile struct {
+		Nickname sql.NullString `json:"nickname"`
+		Age      sql.NullInt64  `json:"age"`
+		Website  url.URL        `json:"website"`
+	}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Profile{}, nil, openapi3gen.UseAllExportedFields())
+	require.NoError(t, err)
+	require.Equal(t, "object", schemaRef.Value.Properties["nickname"].Value.Type)
+	require.Equal(t, "object", schemaRef.Value.Properties["website"].Value.Type)
+
+	// The schemas describe what encoding/json produces
+	nickname := sql.NullString{String: "Rex", Valid: true}
+	data, err := json.Marshal(nickname)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"String": "Rex", "Valid": true}`, string(data))
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	require.NoError(t, err)
+	err = schemaRef.Value.Properties["nickname"].Value.VisitJSON(value)
+	require.NoError(t, err)
+}
+
+func TestTypeSchema(t *testing.T) {
+	type Code string
+	type Ticket struct {
+		Code    Code    `json:"code" openapi:"example=AB-12"`
+		Version Version `json:"version"`
+	}
+
+	schemaRef, err := openapi3gen.NewSchemaRefForValue(&Ticket{}, nil,
+		openapi3gen.TypeSchema(reflect.TypeOf(Code("")), &openapi3.Schema{Type: "string", Pattern: "^[A-Z]{2}-[0-9]+$"}),
+		openapi3gen.TypeSchema(reflect.TypeOf(Version{}), &openapi3.Schema{Type: "string", Pattern: `^\d+\.\d+$`}),
+	)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(schemaRef)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "type": "object",
+  "properties": {
+    "code": {"type": "string", "pattern": "^[A-Z]{2}-[0-9]+$", "example": "AB-12"},
+    "version": {"type": "string", "pattern": "^\\d+\\.\\d+$"}
+  }
+}`, string(data))
+
+	// The registered schema is not altered by field tags
+	codeSchema := &openapi3.Schema{Type: "string"}
+	_, err = openapi3gen.NewSchemaRefForValue(&Ticket{}, nil, openapi3gen.TypeSchema(reflect.TypeOf(Code("")), codeSchema))
+	require.NoError(t, err)
+	require.Equal(t, &openapi3.Schema{Type: "string"}, codeSchema)
+}